@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go-adapt/internal/authoring"
+	"go-adapt/internal/content"
+	"go-adapt/internal/evaluation"
+	"log"
+	"os"
+	"sort"
+)
+
+// evaluate scores knowledge-tracing predictions against logged answer sequences.
+// Each answer is predicted before the model is updated with it.
+func main() {
+	logPath := flag.String("log", "", "JSON Lines answer or event log (required)")
+	folds := flag.Int("folds", 0, "k-fold cross-validation by learner with per-skill parameter fitting (0 = off)")
+	fit := flag.Bool("fit", false, "fit per-skill BKT parameters on the whole log instead of using -l0/-t/-s/-g (optimistic without -folds)")
+	bySkill := flag.Bool("skills", true, "model each skill of the skill graph separately")
+	l0 := flag.Float64("l0", 0.02, "BKT prior knowledge")
+	t := flag.Float64("t", 0.1, "BKT learn rate")
+	s := flag.Float64("s", 0.05, "BKT slip")
+	g := flag.Float64("g", 0.2, "BKT guess")
+	seed := flag.Int64("seed", 1, "fold assignment seed")
+	asJSON := flag.Bool("json", false, "print the full result as JSON")
+	templateSeed := flag.Int64("template-seed", 0, "TEMPLATE_BANK_SEED the server ran with (0 = no templated questions)")
+	templateVariants := flag.Int("template-variants", 1, "TEMPLATE_BANK_VARIANTS the server ran with")
+	draftPath := flag.String("drafts", "", "draft queue the server ran with, for approved questions")
+	flag.Parse()
+
+	if *logPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(*logPath)
+	if err != nil {
+		log.Fatalf("Failed to open log: %v", err)
+	}
+	answers, err := evaluation.LoadAnswers(file)
+	file.Close()
+	if err != nil {
+		log.Fatalf("Failed to read log: %v", err)
+	}
+
+	skillsOf := func(int) []string { return []string{"all"} }
+	if *bySkill {
+		graph, err := content.NewMedicalTerminologySkillGraph()
+		if err != nil {
+			log.Fatalf("Invalid skill graph: %v", err)
+		}
+		bank, err := authoring.ServedBank(*templateSeed, *templateVariants, *draftPath)
+		if err != nil {
+			log.Fatalf("Failed to load questions: %v", err)
+		}
+		skillsOf = func(questionID int) []string {
+			question, err := bank.GetQuestionByID(questionID)
+			if err != nil {
+				return []string{"unknown question"}
+			}
+			if skills := graph.SkillsFor(question); len(skills) > 0 {
+				return skills
+			}
+			return []string{"unmapped"}
+		}
+	}
+	seqs := evaluation.GroupSequences(answers, skillsOf)
+
+	var result evaluation.Result
+	switch {
+	case *folds > 0:
+		result, err = evaluation.CrossValidate(seqs, *folds, evaluation.FitBKT, *seed)
+		if err != nil {
+			log.Fatalf("Cross-validation failed: %v", err)
+		}
+	case *fit:
+		train := make(map[string][][]bool)
+		for skill, learners := range seqs {
+			for _, seq := range learners {
+				train[skill] = append(train[skill], seq)
+			}
+		}
+		result = evaluation.Evaluate(seqs, evaluation.FitBKT(train))
+	default:
+		result = evaluation.Evaluate(seqs, evaluation.BKTFactory(*l0, *t, *s, *g))
+	}
+
+	if *asJSON {
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to encode result: %v", err)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	fmt.Printf("%d answers", len(answers))
+	if result.Folds > 0 {
+		fmt.Printf(", %d-fold cross-validation by learner", result.Folds)
+	}
+	fmt.Print("\n\n")
+
+	fmt.Printf("%-22s %7s %7s %7s %9s\n", "skill", "n", "auc", "rmse", "log_loss")
+	skills := make([]string, 0, len(result.PerSkill))
+	for skill := range result.PerSkill {
+		skills = append(skills, skill)
+	}
+	sort.Strings(skills)
+	for _, skill := range skills {
+		printScores(skill, result.PerSkill[skill])
+	}
+	printScores("overall", result.Overall)
+
+	fmt.Printf("\ncalibration (overall)\n%-11s %7s %10s %9s\n", "bin", "n", "predicted", "observed")
+	for _, bin := range result.Overall.Calibration {
+		fmt.Printf("%.1f - %.1f  %7d %10.3f %9.3f\n", bin.Lower, bin.Upper, bin.Count, bin.MeanPredicted, bin.ObservedRate)
+	}
+}
+
+// printScores shows "-" for an undefined AUC, with the reason after the row
+func printScores(name string, s evaluation.Scores) {
+	auc := "-"
+	if s.AUC != nil {
+		auc = fmt.Sprintf("%.3f", *s.AUC)
+	}
+	fmt.Printf("%-22s %7d %7s %7.3f %9.3f", name, s.N, auc, s.RMSE, s.LogLoss)
+	if s.AUCSkipped != "" {
+		fmt.Printf("  (%s)", s.AUCSkipped)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go-adapt/internal/authoring"
+	"go-adapt/internal/evaluation"
+	"log"
+	"os"
+	"strings"
+)
+
+// itemanalysis reports, per question, how often each option is chosen, the
+// point-biserial discrimination, and distractors that nobody picks. With
+// -positions it reports choices by display position instead, to spot position bias.
+func main() {
+	logPath := flag.String("log", "", "JSON Lines answer or event log (required)")
+	byDiscrimination := flag.Bool("sort", false, "list the least discriminating questions first")
+	asJSON := flag.Bool("json", false, "print the full report as JSON")
+	byPosition := flag.Bool("positions", false, "report choices by the position options were displayed in")
+	templateSeed := flag.Int64("template-seed", 0, "TEMPLATE_BANK_SEED the server ran with (0 = no templated questions)")
+	templateVariants := flag.Int("template-variants", 1, "TEMPLATE_BANK_VARIANTS the server ran with")
+	draftPath := flag.String("drafts", "", "draft queue the server ran with, for approved questions")
+	flag.Parse()
+
+	if *logPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(*logPath)
+	if err != nil {
+		log.Fatalf("Failed to open log: %v", err)
+	}
+	answers, err := evaluation.LoadAnswers(file)
+	file.Close()
+	if err != nil {
+		log.Fatalf("Failed to read log: %v", err)
+	}
+
+	bank, err := authoring.ServedBank(*templateSeed, *templateVariants, *draftPath)
+	if err != nil {
+		log.Fatalf("Failed to load questions: %v", err)
+	}
+	questions, err := bank.GetAll()
+	if err != nil {
+		log.Fatalf("Failed to load questions: %v", err)
+	}
+	if *byPosition {
+		reportPositions(evaluation.AnalyzePositions(answers, questions), *asJSON)
+		return
+	}
+
+	items := evaluation.AnalyzeItems(answers, questions)
+	if *byDiscrimination {
+		evaluation.SortByDiscrimination(items)
+	}
+
+	if *asJSON {
+		out, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to encode report: %v", err)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	fmt.Printf("%d answers\n", len(answers))
+	for _, item := range items {
+		fmt.Printf("\nquestion %d: n=%d p=%.2f r_pb=%.2f", item.QuestionID, item.N, item.PValue, item.PointBiserial)
+		if item.Unrecorded > 0 || item.Unmatched > 0 {
+			fmt.Printf(" (unrecorded=%d unmatched=%d)", item.Unrecorded, item.Unmatched)
+		}
+		fmt.Println()
+		for _, option := range item.Options {
+			marker := " "
+			if option.Correct {
+				marker = "*"
+			}
+			fmt.Printf("  %s %-32s %5d %6.1f%%\n", marker, option.Option, option.Count, option.Proportion*100)
+		}
+		if item.N > 0 && len(item.UnusedDistractors) > 0 {
+			fmt.Printf("  unused distractors: %s\n", strings.Join(item.UnusedDistractors, ", "))
+		}
+	}
+}
+
+func reportPositions(positions []evaluation.PositionStats, asJSON bool) {
+	if asJSON {
+		out, err := json.MarshalIndent(positions, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to encode report: %v", err)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	fmt.Printf("%8s %7s %7s %8s %9s %9s\n", "position", "shown", "chosen", "chosen%", "key_shown", "key_p")
+	for _, p := range positions {
+		fmt.Printf("%8d %7d %7d %7.1f%% %9d %9.2f\n", p.Position, p.Shown, p.Chosen, p.ChosenRate*100, p.KeyShown, p.KeyPValue)
+	}
+}
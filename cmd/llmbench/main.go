@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/llm"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// llmbench replays a fixed answer sequence through SelectNextQuestion and
+// reports per-call latency and token usage, so the effect of prompt caching
+// can be measured against the live API. For an offline comparison with the
+// legacy request shape, run go test -bench SelectNextRequest ./internal/llm.
+func main() {
+	calls := flag.Int("calls", 10, "number of answers to replay")
+	promptVersion := flag.String("prompt", llm.DefaultPromptVersion, "prompt template version")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Println("No .env file found - using system environment variables")
+	}
+
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if apiKey == "" {
+		log.Fatal("ANTHROPIC_API_KEY not set")
+	}
+
+	bank := content.NewStaticBank()
+	questions, err := bank.GetAll()
+	if err != nil {
+		log.Fatalf("Failed to load question bank: %v", err)
+	}
+	prompts, err := llm.LoadDefaultPrompts()
+	if err != nil {
+		log.Fatalf("Failed to load prompt templates: %v", err)
+	}
+	client := llm.NewLLMClient(apiKey, prompts)
+
+	var history []content.AnswerRecord
+	var totalLatency time.Duration
+	var totalInput, totalCacheRead, totalCacheWrite, totalOutput int64
+
+	fmt.Println("call  latency    input  cache_read  cache_write  output")
+	for i := 0; i < *calls && i < len(questions); i++ {
+		// Alternate two correct answers with one incorrect to give the model a realistic history
+		record := content.AnswerRecord{
+			QuestionID: questions[i].ID,
+			Correct:    i%3 != 2,
+			UserAnswer: questions[i].Answer,
+		}
+		if record.Correct {
+			record.Score = 1
+		} else {
+			record.UserAnswer = firstDistractor(questions[i])
+		}
+		history = append(history, record)
+
+		resp, err := client.SelectNextQuestion(*promptVersion, questions, history)
+		if err != nil {
+			log.Fatalf("Call %d failed: %v", i+1, err)
+		}
+		u := resp.Usage
+		fmt.Printf("%4d  %7s  %7d  %10d  %11d  %6d\n", i+1, u.Latency.Round(time.Millisecond),
+			u.InputTokens, u.CacheReadInputTokens, u.CacheCreationInputTokens, u.OutputTokens)
+
+		totalLatency += u.Latency
+		totalInput += u.InputTokens
+		totalCacheRead += u.CacheReadInputTokens
+		totalCacheWrite += u.CacheCreationInputTokens
+		totalOutput += u.OutputTokens
+	}
+
+	n := len(history)
+	if n == 0 {
+		return
+	}
+	fmt.Printf("\nmean latency: %s\n", (totalLatency / time.Duration(n)).Round(time.Millisecond))
+	fmt.Printf("uncached input tokens: %d, cache reads: %d, cache writes: %d, output: %d\n",
+		totalInput, totalCacheRead, totalCacheWrite, totalOutput)
+
+	// For reference: the size of the indented bank + full history the previous encoding re-sent on every call
+	legacyBank, _ := json.MarshalIndent(questions, "", "  ")
+	legacyHistory, _ := json.MarshalIndent(history, "", "  ")
+	fmt.Printf("legacy per-call payload: %d bytes (bank %d, history %d)\n",
+		len(legacyBank)+len(legacyHistory), len(legacyBank), len(legacyHistory))
+}
+
+func firstDistractor(q content.Question) string {
+	for _, option := range q.Options {
+		if option != q.Answer {
+			return option
+		}
+	}
+	return ""
+}
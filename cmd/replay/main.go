@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go-adapt/internal/authoring"
+	"go-adapt/internal/content"
+	"go-adapt/internal/eventlog"
+	"go-adapt/internal/selection"
+	"go-adapt/internal/session"
+	"log"
+	"os"
+)
+
+// replay rebuilds one session from the event log and prints its metrics as
+// GET /session/metrics showed them after the last logged event. Selectors run
+// against throwaway stores and without an LLM client, so replaying changes
+// nothing and costs nothing; LLM and hybrid sessions can't be replayed. Bandit
+// sessions start from a copy of the statistics in -bandit, if given.
+func main() {
+	logPath := flag.String("log", "events.jsonl", "JSON Lines event log")
+	sessionID := flag.String("session", "", "session to replay (required)")
+	templateSeed := flag.Int64("template-seed", 0, "TEMPLATE_BANK_SEED the server ran with (0 = no templated questions)")
+	templateVariants := flag.Int("template-variants", 1, "TEMPLATE_BANK_VARIANTS the server ran with")
+	draftPath := flag.String("drafts", "", "draft queue the server ran with, for approved questions")
+	banditPath := flag.String("bandit", "", "bandit store to start bandit sessions from (read, never written)")
+	flag.Parse()
+
+	if *sessionID == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(*logPath)
+	if err != nil {
+		log.Fatalf("Failed to open event log: %v", err)
+	}
+	events, err := eventlog.ReadAll(file)
+	file.Close()
+	if err != nil {
+		log.Fatalf("Failed to read event log: %v", err)
+	}
+	events = eventlog.ForSession(events, *sessionID)
+	if len(events) == 0 {
+		log.Fatalf("No events for session %s", *sessionID)
+	}
+
+	bank, err := authoring.ServedBank(*templateSeed, *templateVariants, *draftPath)
+	if err != nil {
+		log.Fatalf("Failed to load questions: %v", err)
+	}
+	skillGraph, err := content.NewMedicalTerminologySkillGraph()
+	if err != nil {
+		log.Fatalf("Invalid skill graph: %v", err)
+	}
+
+	deps := selection.Deps{QuestionBank: bank, SkillGraph: skillGraph}
+	if *banditPath != "" {
+		if deps.BanditStore, err = selection.NewBanditFileStore(*banditPath); err != nil {
+			log.Fatalf("Failed to open bandit store: %v", err)
+		}
+	}
+	manager, err := session.Replay(events, bank, session.RegistryBuilder(selection.DefaultRegistry(), deps))
+	if err != nil {
+		log.Fatalf("Replay failed: %v", err)
+	}
+
+	out, err := json.MarshalIndent(manager.GetMetrics(), "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode metrics: %v", err)
+	}
+	fmt.Println(string(out))
+}
@@ -2,11 +2,16 @@ package main
 
 import (
 	"fmt"
+	"go-adapt/internal/authoring"
 	"go-adapt/internal/content"
+	"go-adapt/internal/eventlog"
 	"go-adapt/internal/handler"
 	"go-adapt/internal/llm"
+	"go-adapt/internal/review"
+	"go-adapt/internal/selection"
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -22,16 +27,103 @@ func main() {
 	// Setup
 	apiKey := os.Getenv("ANTHROPIC_API_KEY")
 
-	bank := content.NewStaticBank()
-	llmClient := llm.NewLLMClient(apiKey)
+	// Load prompt templates (PROMPTS_DIR overrides the embedded defaults)
+	var prompts *llm.PromptSet
+	if dir := os.Getenv("PROMPTS_DIR"); dir != "" {
+		prompts, err = llm.LoadPrompts(os.DirFS(dir), llm.DefaultPromptVars())
+	} else {
+		prompts, err = llm.LoadDefaultPrompts()
+	}
+	if err != nil {
+		log.Fatalf("Failed to load prompt templates: %v", err)
+	}
+	fmt.Printf("Loaded prompt versions: %v\n", prompts.Versions())
+
+	// Generated questions wait in the draft queue; approved ones join the static bank
+	draftPath := os.Getenv("DRAFT_QUEUE_PATH")
+	if draftPath == "" {
+		draftPath = "drafts.json"
+	}
+	draftQueue, err := authoring.NewQueue(draftPath)
+	if err != nil {
+		log.Fatalf("Failed to open draft queue: %v", err)
+	}
+	// TEMPLATE_BANK_SEED adds questions generated from the word-part lexicon,
+	// TEMPLATE_BANK_VARIANTS (default 1) of each with different distractors
+	var base content.QuestionBank = content.NewStaticBank()
+	if seedVar := os.Getenv("TEMPLATE_BANK_SEED"); seedVar != "" {
+		seed, err := strconv.ParseInt(seedVar, 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid TEMPLATE_BANK_SEED: %v", err)
+		}
+		variants := 1
+		if variantsVar := os.Getenv("TEMPLATE_BANK_VARIANTS"); variantsVar != "" {
+			if variants, err = strconv.Atoi(variantsVar); err != nil {
+				log.Fatalf("Invalid TEMPLATE_BANK_VARIANTS: %v", err)
+			}
+		}
+		templates, err := content.NewTemplateBank(content.MedicalLexicon(), seed, content.TemplateIDBase, variants)
+		if err != nil {
+			log.Fatalf("Invalid template bank: %v", err)
+		}
+		base = content.NewMultiBank(base, templates)
+	}
+	bank := authoring.NewBank(base, draftQueue)
+	questions, _ := bank.GetAll()
+	if err := content.ValidateQuestions(questions); err != nil {
+		log.Fatalf("Invalid question bank: %v", err)
+	}
+	// Missing option feedback isn't fatal; those options fall back to the general feedback
+	for _, issue := range content.ValidateFeedback(questions) {
+		log.Printf("Feedback: %s", issue)
+	}
+	// Without a key the client stays nil, so every LLM feature reports itself unavailable
+	var llmClient *llm.LLMClient
 	if apiKey != "" {
-		llmClient = llm.NewLLMClient(apiKey)
+		llmClient = llm.NewLLMClient(apiKey, prompts)
 		fmt.Println("LLM client initialized (LLM mode available)")
 	} else {
 		fmt.Println("ANTHROPIC_API_KEY not set - LLM mode disabled")
 	}
 
-	h := handler.NewHandler(bank, llmClient)
+	// Spaced-repetition schedules persist between runs
+	reviewPath := os.Getenv("REVIEW_STORE_PATH")
+	if reviewPath == "" {
+		reviewPath = "reviews.json"
+	}
+	reviewStore, err := review.NewFileStore(reviewPath)
+	if err != nil {
+		log.Fatalf("Failed to open review store: %v", err)
+	}
+
+	// So are the bandit's item statistics
+	banditPath := os.Getenv("BANDIT_STORE_PATH")
+	if banditPath == "" {
+		banditPath = "bandit.json"
+	}
+	banditStore, err := selection.NewBanditFileStore(banditPath)
+	if err != nil {
+		log.Fatalf("Failed to open bandit store: %v", err)
+	}
+
+	// Validate the skill prerequisite graph at startup so a cycle fails fast
+	skillGraph, err := content.NewMedicalTerminologySkillGraph()
+	if err != nil {
+		log.Fatalf("Invalid skill graph: %v", err)
+	}
+
+	// Every session interaction is appended to the event log
+	eventPath := os.Getenv("EVENT_LOG_PATH")
+	if eventPath == "" {
+		eventPath = "events.jsonl"
+	}
+	events, err := eventlog.OpenJSONL(eventPath)
+	if err != nil {
+		log.Fatalf("Failed to open event log: %v", err)
+	}
+	defer events.Close()
+
+	h := handler.NewHandler(bank, llmClient, reviewStore, banditStore, skillGraph, events, bank)
 
 	// Define routes
 	r := gin.Default()
@@ -39,8 +131,17 @@ func main() {
 	// API routes
 	r.POST("/session/start", h.StartSession)
 	r.GET("/session/question", h.GetNextQuestion)
+	r.POST("/session/hint", h.GetHint)
 	r.POST("/session/answer", h.SubmitAnswer)
 	r.GET("/session/metrics", h.GetMetrics)
+	r.GET("/reviews", h.GetReviews)
+	r.GET("/modes", h.GetModes)
+	// Draft review is for admins only: ADMIN_TOKEN must be sent as a bearer token
+	drafts := r.Group("/drafts", handler.RequireAdminToken(os.Getenv("ADMIN_TOKEN")))
+	drafts.POST("/generate", h.GenerateDrafts)
+	drafts.GET("", h.GetDrafts)
+	drafts.POST("/:id/approve", h.ApproveDraft)
+	drafts.POST("/:id/reject", h.RejectDraft)
 
 	// Serve static frontend files (must come after API routes)
 	r.Static("/static", "./frontend")
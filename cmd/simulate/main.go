@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/llm"
+	"go-adapt/internal/review"
+	"go-adapt/internal/selection"
+	"go-adapt/internal/simulation"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// simulate runs synthetic learners through one or more selection modes and
+// compares learning gain, questions-to-mastery and estimate error.
+func main() {
+	modes := flag.String("modes", "bkt,irt,prereq,bandit-ucb", "comma-separated selection modes to compare")
+	learnerModel := flag.String("learners", simulation.ModelBKT, "learner generative model: bkt (skills known or not) or irt (an ability per skill); both learn the skills of the items they get, most from items matching their level")
+	runs := flag.Int("runs", 2000, "simulated sessions per mode")
+	questions := flag.Int("questions", 10, "answers per session")
+	threshold := flag.Float64("mastery", 0, "ground-truth mastery threshold (default 0.95 for bkt learners, 0.8 for irt)")
+	seed := flag.Int64("seed", 1, "random seed")
+	workers := flag.Int("workers", runtime.NumCPU(), "parallel workers")
+	bankName := flag.String("bank", "static", "question bank: static, templates (generated from the word-part lexicon) or both")
+	bankSeed := flag.Int64("bank-seed", 1, "seed for the templated bank's distractors and option order")
+	bankVariants := flag.Int("bank-variants", 1, "variants of each templated question, each with its own distractors")
+	flag.Parse()
+
+	err := godotenv.Load()
+	if err != nil {
+		log.Println("No .env file found - using system environment variables")
+	}
+
+	skillGraph, err := content.NewMedicalTerminologySkillGraph()
+	if err != nil {
+		log.Fatalf("Invalid skill graph: %v", err)
+	}
+	bank, err := questionBank(*bankName, *bankSeed, *bankVariants)
+	if err != nil {
+		log.Fatal(err)
+	}
+	deps := selection.Deps{
+		QuestionBank: bank,
+		BanditStore:  selection.NewBanditStore(),
+		ReviewStore:  review.NewMemoryStore(),
+		SkillGraph:   skillGraph,
+	}
+	// LLM-backed modes make one API call per answer; only enable them when a key is set
+	if apiKey := os.Getenv("ANTHROPIC_API_KEY"); apiKey != "" {
+		prompts, err := llm.LoadDefaultPrompts()
+		if err != nil {
+			log.Fatalf("Failed to load prompt templates: %v", err)
+		}
+		deps.LLMClient = llm.NewLLMClient(apiKey, prompts)
+	}
+
+	registry := selection.DefaultRegistry()
+
+	fmt.Printf("%d %s learners x %d questions, seed %d\n\n", *runs, *learnerModel, *questions, *seed)
+	fmt.Printf("%-16s %6s %8s %8s %9s %11s %-12s %10s %7s\n", "mode", "runs", "gain", "premaster", "mastered", "q_to_master", "estimator", "final_err", "rmse")
+	for _, mode := range strings.Split(*modes, ",") {
+		mode = strings.TrimSpace(mode)
+		params := map[string]any{}
+		if mode == "review" {
+			params["learner_id"] = "sim" // Replaced per run
+		}
+
+		report, err := simulation.Run(registry, deps, simulation.Config{
+			Mode:             mode,
+			Params:           params,
+			LearnerModel:     *learnerModel,
+			Runs:             *runs,
+			Questions:        *questions,
+			MasteryThreshold: *threshold,
+			Seed:             *seed,
+			Workers:          *workers,
+			L0:               0.02,
+			T:                0.1,
+			S:                0.05,
+			G:                0.2,
+		})
+		if err != nil {
+			log.Fatalf("%s: %v", mode, err)
+		}
+		if report.FirstError != nil {
+			log.Printf("%s: %d runs failed: %v", mode, report.Failed, report.FirstError)
+		}
+
+		fmt.Printf("%-16s %6d %8.3f %9d %8.1f%% %11.2f %-12s %10.3f %7.3f\n", report.Mode, report.Runs,
+			report.MeanLearningGain, report.AlreadyMastered, report.MasteryRate*100, report.MeanQuestionsToMastery,
+			report.Estimator, report.MeanAbsFinalError, report.RMSE)
+	}
+}
+
+func questionBank(name string, seed int64, variants int) (content.QuestionBank, error) {
+	if name == "static" {
+		return content.NewStaticBank(), nil
+	}
+	templates, err := content.NewTemplateBank(content.MedicalLexicon(), seed, content.TemplateIDBase, variants)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case "templates":
+		return templates, nil
+	case "both":
+		return content.NewMultiBank(content.NewStaticBank(), templates), nil
+	}
+	return nil, fmt.Errorf("unknown bank %q", name)
+}
@@ -2,6 +2,8 @@
 let sessionID = null;
 let currentMode = null;
 let currentQuestion = null;
+let currentSchema = null; // How the current question expects to be answered
+let questionShownAt = null; // When the current question was displayed, for response time
 let questionsAnswered = 0;
 let correctAnswers = 0;
 
@@ -20,6 +22,9 @@ const optionsContainer = document.getElementById('options');
 const feedbackDiv = document.getElementById('feedback');
 const feedbackText = document.getElementById('feedback-text');
 const correctAnswerText = document.getElementById('correct-answer');
+const hintBtn = document.getElementById('hint-btn');
+const hintList = document.getElementById('hint-list');
+const confidenceFieldset = document.getElementById('confidence');
 
 const questionNumSpan = document.getElementById('question-num');
 const progressFill = document.getElementById('progress-fill');
@@ -47,6 +52,7 @@ let knowledgeChart = null;
 // Event listeners
 startBtn.addEventListener('click', startSession);
 restartBtn.addEventListener('click', resetQuiz);
+hintBtn.addEventListener('click', showHint);
 // Note: nextBtn onclick is set dynamically in selectAnswer()
 
 // HTML Sanitizer - allows only safe formatting tags
@@ -143,10 +149,20 @@ async function loadNextQuestion() {
         const data = await response.json();
         hideLoading();
         currentQuestion = data.question;
+        currentSchema = data.answer_schema;
 
         // Update UI
-        displayQuestion(currentQuestion);
+        displayQuestion(currentQuestion, currentSchema);
         updateProgress();
+        questionShownAt = performance.now();
+
+        // Hints are revealed one at a time; using them makes a correct answer count for less
+        hintList.innerHTML = '';
+        setHintButton(data.hints_available || 0);
+
+        // Confidence is rated before answering, so clear the last question's rating
+        confidenceFieldset.querySelectorAll('input').forEach(input => input.checked = false);
+        confidenceFieldset.style.display = 'block';
 
         // Hide LLM feedback when loading new question
         llmFeedbackDiv.style.display = 'none';
@@ -167,24 +183,135 @@ async function loadNextQuestion() {
 }
 
 // Display a question
-function displayQuestion(question) {
+function displayQuestion(question, schema) {
     questionText.textContent = question.Text;
     optionsContainer.innerHTML = '';
 
-    question.Options.forEach(option => {
-        const button = document.createElement('button');
-        button.className = 'option-btn';
-        button.textContent = option;
-        button.addEventListener('click', () => selectAnswer(option));
+    switch (schema ? schema.type : 'multiple_choice') {
+        case 'free_text':
+        case 'explanation':
+            displayTextInputs(1, values => values[0]);
+            break;
+        case 'term_building':
+            displayTextInputs(schema.count, values => values);
+            break;
+        case 'multi_select':
+            displayMultiSelect(question.Options);
+            break;
+        case 'ordering':
+            displayOrdering(question.Options);
+            break;
+        default:
+            question.Options.forEach(option => {
+                optionsContainer.appendChild(makeOptionButton(option, () => selectAnswer(option)));
+            });
+    }
+}
+
+function makeOptionButton(text, onClick) {
+    const button = document.createElement('button');
+    button.className = 'option-btn';
+    button.textContent = text;
+    button.addEventListener('click', onClick);
+    return button;
+}
+
+// One text box per answer; toAnswer shapes the values for the answer schema
+function displayTextInputs(count, toAnswer) {
+    const inputs = [];
+    for (let i = 0; i < count; i++) {
+        const input = document.createElement('input');
+        input.type = 'text';
+        input.className = 'answer-input';
+        input.placeholder = count > 1 ? `Part ${i + 1}` : 'Type your answer';
+        optionsContainer.appendChild(input);
+        inputs.push(input);
+    }
+    optionsContainer.appendChild(makeOptionButton('Submit', () => {
+        inputs.forEach(input => input.disabled = true);
+        selectAnswer(toAnswer(inputs.map(input => input.value)));
+    }));
+}
+
+// Options toggle on and off; Submit sends every selected option
+function displayMultiSelect(options) {
+    const selected = new Set();
+    options.forEach(option => {
+        const button = makeOptionButton(option, () => {
+            if (selected.has(option)) {
+                selected.delete(option);
+            } else {
+                selected.add(option);
+            }
+            button.classList.toggle('selected');
+        });
+        optionsContainer.appendChild(button);
+    });
+    optionsContainer.appendChild(makeOptionButton('Submit', () => selectAnswer([...selected])));
+}
+
+// Options are clicked in order; the answer is sent once every option is placed
+function displayOrdering(options) {
+    const order = [];
+    const orderText = document.createElement('p');
+    orderText.className = 'order-preview';
+    optionsContainer.appendChild(orderText);
+    options.forEach(option => {
+        const button = makeOptionButton(option, () => {
+            button.disabled = true;
+            order.push(option);
+            orderText.textContent = order.join(' + ');
+            if (order.length === options.length) {
+                selectAnswer([...order]);
+            }
+        });
         optionsContainer.appendChild(button);
     });
 }
 
+// Show the hint button while hints remain
+function setHintButton(remaining) {
+    hintBtn.style.display = remaining > 0 ? 'inline-block' : 'none';
+    hintBtn.textContent = hintList.children.length === 0 ? 'Show a hint' : `Show another hint (${remaining} left)`;
+}
+
+// Reveal the next hint for the current question
+async function showHint() {
+    hintBtn.disabled = true;
+    try {
+        const response = await fetch('/session/hint', {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({
+                session_id: sessionID,
+                question_id: currentQuestion.ID
+            })
+        });
+        const data = await response.json();
+        if (!response.ok) {
+            throw new Error(data.error || 'Failed to load hint');
+        }
+
+        const item = document.createElement('li');
+        item.textContent = data.hint;
+        hintList.appendChild(item);
+        setHintButton(data.hints_remaining);
+    } catch (error) {
+        hintBtn.style.display = 'none';
+        alert('Error loading hint: ' + error.message);
+    } finally {
+        hintBtn.disabled = false;
+    }
+}
+
 // Handle answer selection
 async function selectAnswer(selectedAnswer) {
     // Disable all option buttons
     const optionButtons = document.querySelectorAll('.option-btn');
     optionButtons.forEach(btn => btn.disabled = true);
+    hintBtn.style.display = 'none';
+    confidenceFieldset.style.display = 'none';
+    const rated = confidenceFieldset.querySelector('input:checked');
 
     showLoading();
 
@@ -195,7 +322,10 @@ async function selectAnswer(selectedAnswer) {
             body: JSON.stringify({
                 session_id: sessionID,
                 question_id: currentQuestion.ID,
-                user_answer: selectedAnswer
+                user_answer: selectedAnswer,
+                // Measured here so network and loading time don't count as thinking time
+                client_latency_ms: Math.round(performance.now() - questionShownAt),
+                confidence: rated ? Number(rated.value) : 0
             })
         });
 
@@ -214,6 +344,10 @@ async function selectAnswer(selectedAnswer) {
 
         // Show feedback
         displayFeedback(data.correct, data.correct_answer, selectedAnswer);
+        if (!data.correct && data.score > 0) {
+            const closeness = data.match_type === 'near_miss' ? 'Close spelling' : 'Partly right';
+            correctAnswerText.textContent += ` (${closeness}: ${Math.round(data.score * 100)}% credit)`;
+        }
 
         // Show feedback if available (LLM personalized or BKT static)
         if (data.feedback) {
@@ -0,0 +1,62 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileReplacesContents(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "store.json")
+	for _, contents := range []string{"first", "second"} {
+		if err := WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != contents {
+			t.Errorf("file holds %q, want %q", data, contents)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o644 {
+		t.Errorf("file mode %v, want 0644", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("directory holds %d files, want only the written file", len(entries))
+	}
+}
+
+func TestWriteFileFailureLeavesOldContents(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "store.json")
+	if err := WriteFile(path, []byte("kept"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Renaming over a non-empty directory fails
+	blocked := filepath.Join(dir, "blocked")
+	if err := os.MkdirAll(filepath.Join(blocked, "child"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(blocked, []byte("lost"), 0o644); err == nil {
+		t.Fatal("write over a directory succeeded")
+	}
+	if err := WriteFile(filepath.Join(dir, "missing", "store.json"), []byte("lost"), 0o644); err == nil {
+		t.Fatal("write into a missing directory succeeded")
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "kept" {
+		t.Errorf("file holds %q after failed writes, want %q", data, "kept")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("directory holds %d entries after failed writes, want no temp files", len(entries))
+	}
+}
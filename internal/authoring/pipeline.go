@@ -0,0 +1,224 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"go-adapt/internal/content"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Question generation: the LLM drafts new multiple-choice items for a tag and
+// difficulty, modeled on existing ones. Drafts that pass validation wait in the
+// review queue; only approved drafts enter the bank.
+
+// MaxGenerate caps how many questions one generation request may ask for
+const MaxGenerate = 10
+
+// exampleCount is how many existing questions are shown to the generator as models
+const exampleCount = 3
+
+// Generator writes new questions modeled on examples, using only tags from taxonomy
+type Generator interface {
+	GenerateQuestions(ctx context.Context, examples []content.Question, taxonomy []string, tag string, difficulty float64, count int) ([]content.Question, error)
+}
+
+// RejectedQuestion is a generated question that failed validation
+type RejectedQuestion struct {
+	Question content.Question `json:"question"`
+	Problems []string         `json:"problems"`
+}
+
+// Result is the outcome of one generation request
+type Result struct {
+	Drafts   []Draft            `json:"drafts"`   // Queued for review
+	Rejected []RejectedQuestion `json:"rejected"` // Failed validation and were dropped
+}
+
+// Generate asks gen for count questions on tag at difficulty, validates them
+// against the bank and the queue, and queues the valid ones as pending drafts.
+// ctx bounds the call to gen.
+func Generate(ctx context.Context, gen Generator, bank *Bank, taxonomy map[string]bool, tag string, difficulty float64, count int, now time.Time) (*Result, error) {
+	if !taxonomy[tag] {
+		return nil, fmt.Errorf("unknown tag %q", tag)
+	}
+	if difficulty < 0 || difficulty > 1 {
+		return nil, fmt.Errorf("difficulty must be between 0 and 1")
+	}
+	if count < 1 || count > MaxGenerate {
+		return nil, fmt.Errorf("count must be between 1 and %d", MaxGenerate)
+	}
+
+	questions, err := bank.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(taxonomy))
+	for t := range taxonomy {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+
+	generated, err := gen.GenerateQuestions(ctx, examplesFor(questions, tag, difficulty), tags, tag, difficulty, count)
+	if err != nil {
+		return nil, err
+	}
+
+	// New questions must differ from the bank, from drafts awaiting review and from each other
+	existing := append([]content.Question{}, questions...)
+	for _, draft := range bank.queue.List(Pending) {
+		existing = append(existing, draft.Question)
+	}
+
+	result := &Result{Drafts: []Draft{}, Rejected: []RejectedQuestion{}}
+	var valid []content.Question
+	for _, q := range generated {
+		q.Type = content.MultipleChoice
+		if q.Metadata.IRT == (content.IRTParams{}) {
+			q.Metadata.IRT = uncalibratedIRT(q)
+		}
+		if problems := Validate(q, existing, taxonomy); len(problems) > 0 {
+			result.Rejected = append(result.Rejected, RejectedQuestion{Question: q, Problems: problems})
+			continue
+		}
+		valid = append(valid, q)
+		existing = append(existing, q)
+	}
+
+	result.Drafts, err = bank.queue.Add(valid, tag, difficulty, now)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// examplesFor picks the questions closest in difficulty among those with the
+// tag, or among all questions if none has it
+func examplesFor(questions []content.Question, tag string, difficulty float64) []content.Question {
+	var candidates []content.Question
+	for _, q := range questions {
+		for _, t := range q.Metadata.Tags {
+			if t == tag {
+				candidates = append(candidates, q)
+				break
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = append(candidates, questions...)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return math.Abs(candidates[i].Metadata.Difficulty-difficulty) < math.Abs(candidates[j].Metadata.Difficulty-difficulty)
+	})
+	if len(candidates) > exampleCount {
+		candidates = candidates[:exampleCount]
+	}
+	return candidates
+}
+
+// uncalibratedIRT maps difficulty onto the ability scale the way the static
+// bank does, with a guessing floor of one over the number of options, until
+// the item has response data to calibrate against
+func uncalibratedIRT(q content.Question) content.IRTParams {
+	c := 0.0
+	if len(q.Options) > 0 {
+		c = 1 / float64(len(q.Options))
+	}
+	return content.IRTParams{A: 1.0, B: (q.Metadata.Difficulty - 0.5) * 4, C: c}
+}
+
+// Bank serves a base bank plus every approved draft, so approved questions
+// are available to new sessions without a restart
+type Bank struct {
+	mu    sync.Mutex // Serializes approvals so question IDs stay unique
+	base  content.QuestionBank
+	queue *Queue
+}
+
+func NewBank(base content.QuestionBank, queue *Queue) *Bank {
+	return &Bank{base: base, queue: queue}
+}
+
+// ServedBank rebuilds the bank a server serves, for offline tools: the static
+// bank, templateVariants (TEMPLATE_BANK_VARIANTS) variants of each templated
+// question when templateSeed (TEMPLATE_BANK_SEED) is non-zero, and approved
+// drafts from the queue at draftPath (DRAFT_QUEUE_PATH) when it is set
+func ServedBank(templateSeed int64, templateVariants int, draftPath string) (content.QuestionBank, error) {
+	var bank content.QuestionBank = content.NewStaticBank()
+	if templateSeed != 0 {
+		templates, err := content.NewTemplateBank(content.MedicalLexicon(), templateSeed, content.TemplateIDBase, templateVariants)
+		if err != nil {
+			return nil, err
+		}
+		bank = content.NewMultiBank(bank, templates)
+	}
+	if draftPath != "" {
+		queue, err := NewQueue(draftPath)
+		if err != nil {
+			return nil, err
+		}
+		bank = NewBank(bank, queue)
+	}
+	return bank, nil
+}
+
+// Queue returns the review queue behind the bank
+func (b *Bank) Queue() *Queue {
+	return b.queue
+}
+
+func (b *Bank) GetAll() ([]content.Question, error) {
+	base, err := b.base.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	questions := append([]content.Question{}, base...)
+	for _, draft := range b.queue.List(Approved) {
+		questions = append(questions, draft.Question)
+	}
+	return questions, nil
+}
+
+func (b *Bank) GetQuestionByID(id int) (*content.Question, error) {
+	if q, err := b.base.GetQuestionByID(id); err == nil {
+		return q, nil
+	}
+	for _, draft := range b.queue.List(Approved) {
+		if draft.Question.ID == id {
+			q := draft.Question
+			return &q, nil
+		}
+	}
+	return nil, fmt.Errorf("question ID %d not found", id)
+}
+
+// ApprovedIDBase reserves question IDs above it for approved drafts, clear of
+// the static bank's and the template bank's (content.TemplateIDBase up to
+// content.TemplateIDLimit)
+const ApprovedIDBase = content.TemplateIDLimit
+
+// Approve adds a pending draft to the bank under the next free ID in the
+// approved range. It fails if a base question has strayed into that range.
+func (b *Bank) Approve(draftID string, now time.Time) (Draft, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	base, err := b.base.GetAll()
+	if err != nil {
+		return Draft{}, err
+	}
+	for _, q := range base {
+		if q.ID > ApprovedIDBase {
+			return Draft{}, fmt.Errorf("question ID %d is in the range reserved for approved drafts", q.ID)
+		}
+	}
+	nextID := ApprovedIDBase + 1
+	for _, draft := range b.queue.List(Approved) {
+		if draft.Question.ID >= nextID {
+			nextID = draft.Question.ID + 1
+		}
+	}
+	return b.queue.Approve(draftID, nextID, now)
+}
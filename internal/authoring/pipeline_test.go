@@ -0,0 +1,131 @@
+package authoring
+
+import (
+	"fmt"
+	"go-adapt/internal/content"
+	"testing"
+	"time"
+)
+
+type fixedBank []content.Question
+
+func (b fixedBank) GetAll() ([]content.Question, error) {
+	return b, nil
+}
+
+func (b fixedBank) GetQuestionByID(id int) (*content.Question, error) {
+	for _, q := range b {
+		if q.ID == id {
+			return &q, nil
+		}
+	}
+	return nil, fmt.Errorf("question ID %d not found", id)
+}
+
+func queued(t *testing.T, queue *Queue, texts ...string) []Draft {
+	t.Helper()
+	questions := make([]content.Question, len(texts))
+	for i, text := range texts {
+		questions[i] = content.Question{Type: content.MultipleChoice, Text: text}
+	}
+	drafts, err := queue.Add(questions, "cardiology", 0.5, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return drafts
+}
+
+func TestApproveUsesReservedIDs(t *testing.T) {
+	queue, err := NewQueue("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A template item numbered past the static bank, where max(ID)+1 numbering used to land
+	base := fixedBank{{ID: 1}, {ID: 2}, {ID: content.TemplateIDBase + 7}}
+	bank := NewBank(base, queue)
+
+	for i, draft := range queued(t, queue, "first", "second") {
+		approved, err := bank.Approve(draft.ID, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ApprovedIDBase + 1 + i; approved.Question.ID != want {
+			t.Errorf("draft %d approved as question %d, want %d", i, approved.Question.ID, want)
+		}
+	}
+
+	questions, _ := bank.GetAll()
+	if len(questions) != 5 {
+		t.Errorf("bank has %d questions after two approvals, want 5", len(questions))
+	}
+	seen := make(map[int]bool)
+	for _, q := range questions {
+		if seen[q.ID] {
+			t.Errorf("question ID %d used twice", q.ID)
+		}
+		seen[q.ID] = true
+	}
+}
+
+func TestApproveRefusesBaseInReservedRange(t *testing.T) {
+	queue, err := NewQueue("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bank := NewBank(fixedBank{{ID: ApprovedIDBase + 1}}, queue)
+	draft := queued(t, queue, "only")[0]
+	if _, err := bank.Approve(draft.ID, time.Now()); err == nil {
+		t.Error("approved a draft while a base question holds a reserved ID")
+	}
+	if got := queue.List(Pending); len(got) != 1 {
+		t.Errorf("%d pending drafts after the failed approval, want 1", len(got))
+	}
+}
+
+func TestServedBank(t *testing.T) {
+	static, _ := content.NewStaticBank().GetAll()
+	templates, err := content.NewTemplateBank(content.MedicalLexicon(), 3, content.TemplateIDBase, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	draftPath := t.TempDir() + "/drafts.json"
+	queue, err := NewQueue(draftPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft := queued(t, queue, "approved")[0]
+	queued(t, queue, "still pending")
+	if _, err := NewBank(content.NewStaticBank(), queue).Approve(draft.ID, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		seed      int64
+		variants  int
+		draftPath string
+		want      int
+	}{
+		{"static only", 0, 1, "", len(static)},
+		{"with templates", 3, 1, "", len(static) + templates.Len()},
+		{"with template variants", 3, 4, "", len(static) + 4*templates.Len()},
+		{"with approved drafts", 0, 1, draftPath, len(static) + 1},
+		{"everything", 3, 4, draftPath, len(static) + 4*templates.Len() + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bank, err := ServedBank(tt.seed, tt.variants, tt.draftPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			questions, err := bank.GetAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(questions) != tt.want {
+				t.Errorf("%d questions, want %d", len(questions), tt.want)
+			}
+		})
+	}
+}
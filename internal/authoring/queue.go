@@ -0,0 +1,183 @@
+package authoring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-adapt/internal/atomicfile"
+	"go-adapt/internal/content"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Status is where a draft is in review
+type Status string
+
+const (
+	Pending  Status = "pending"
+	Approved Status = "approved"
+	Rejected Status = "rejected"
+)
+
+// Draft is a generated question waiting for, or past, human review.
+// The question gets its bank ID when it is approved.
+type Draft struct {
+	ID         string           `json:"id"`
+	Status     Status           `json:"status"`
+	Question   content.Question `json:"question"`
+	Tag        string           `json:"tag"`        // Tag the question was generated for
+	Difficulty float64          `json:"difficulty"` // Difficulty it was generated for
+	CreatedAt  time.Time        `json:"created_at"`
+	ReviewedAt time.Time        `json:"reviewed_at,omitempty"`
+	Note       string           `json:"note,omitempty"` // Reviewer's reason for rejecting
+}
+
+var (
+	ErrDraftNotFound = errors.New("draft not found")
+	ErrNotPending    = errors.New("draft has already been reviewed")
+)
+
+// Queue holds drafts for review, persisted to a JSON file rewritten on each
+// change (or in memory only when the path is empty). It is safe for concurrent
+// use within a single process.
+type Queue struct {
+	mu     sync.RWMutex
+	path   string
+	drafts map[string]Draft
+	nextID int
+}
+
+func NewQueue(path string) (*Queue, error) {
+	q := &Queue{
+		path:   path,
+		drafts: make(map[string]Draft),
+		nextID: 1,
+	}
+	if path == "" {
+		return q, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read draft queue: %w", err)
+	}
+	if err := json.Unmarshal(data, &q.drafts); err != nil {
+		return nil, fmt.Errorf("failed to parse draft queue %s: %w", path, err)
+	}
+	for id := range q.drafts {
+		if n, err := strconv.Atoi(id); err == nil && n >= q.nextID {
+			q.nextID = n + 1
+		}
+	}
+	return q, nil
+}
+
+// Add stores questions as pending drafts and returns them with their draft IDs
+func (q *Queue) Add(questions []content.Question, tag string, difficulty float64, now time.Time) ([]Draft, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	added := make([]Draft, 0, len(questions))
+	for _, question := range questions {
+		question.ID = 0
+		draft := Draft{
+			ID:         strconv.Itoa(q.nextID),
+			Status:     Pending,
+			Question:   question,
+			Tag:        tag,
+			Difficulty: difficulty,
+			CreatedAt:  now,
+		}
+		q.nextID++
+		q.drafts[draft.ID] = draft
+		added = append(added, draft)
+	}
+	if err := q.save(); err != nil {
+		// Forget the drafts so memory never gets ahead of the file
+		for _, draft := range added {
+			delete(q.drafts, draft.ID)
+		}
+		q.nextID -= len(added)
+		return nil, err
+	}
+	return added, nil
+}
+
+// List returns drafts with the given status, or all drafts for "", oldest first
+func (q *Queue) List(status Status) []Draft {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	var drafts []Draft
+	for _, draft := range q.drafts {
+		if status == "" || draft.Status == status {
+			drafts = append(drafts, draft)
+		}
+	}
+	sort.Slice(drafts, func(i, j int) bool {
+		a, _ := strconv.Atoi(drafts[i].ID)
+		b, _ := strconv.Atoi(drafts[j].ID)
+		return a < b
+	})
+	return drafts
+}
+
+// Approve moves a pending draft into the bank under questionID
+func (q *Queue) Approve(id string, questionID int, now time.Time) (Draft, error) {
+	return q.review(id, func(draft *Draft) {
+		draft.Status = Approved
+		draft.Question.ID = questionID
+		draft.ReviewedAt = now
+	})
+}
+
+// Reject keeps a pending draft out of the bank, recording why
+func (q *Queue) Reject(id, note string, now time.Time) (Draft, error) {
+	return q.review(id, func(draft *Draft) {
+		draft.Status = Rejected
+		draft.Note = note
+		draft.ReviewedAt = now
+	})
+}
+
+func (q *Queue) review(id string, apply func(*Draft)) (Draft, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	draft, ok := q.drafts[id]
+	if !ok {
+		return Draft{}, fmt.Errorf("%w: %s", ErrDraftNotFound, id)
+	}
+	if draft.Status != Pending {
+		return Draft{}, fmt.Errorf("%w: %s is %s", ErrNotPending, id, draft.Status)
+	}
+	previous := draft
+	apply(&draft)
+	q.drafts[id] = draft
+	if err := q.save(); err != nil {
+		q.drafts[id] = previous
+		return Draft{}, err
+	}
+	return draft, nil
+}
+
+// save must be called with the lock held
+func (q *Queue) save() error {
+	if q.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(q.drafts, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := atomicfile.WriteFile(q.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write draft queue: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package authoring
+
+import (
+	"go-adapt/internal/content"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestQueueSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "drafts.json")
+	queue, err := NewQueue(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	drafts := queued(t, queue, "first", "second")
+	if _, err := queue.Reject(drafts[0].ID, "too easy", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewQueue(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reopened.List(Rejected); len(got) != 1 || got[0].Note != "too easy" {
+		t.Errorf("rejected drafts after reopening = %+v", got)
+	}
+	if added := queued(t, reopened, "third"); added[0].ID != "3" {
+		t.Errorf("draft added after reopening got ID %s, want 3", added[0].ID)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("queue directory holds %d files, want only the queue (temp files left behind?)", len(entries))
+	}
+}
+
+func TestQueueFailedSaveKeepsState(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "drafts.json")
+	queue, err := NewQueue(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft := queued(t, queue, "first")[0]
+
+	// Renaming over a non-empty directory fails, so every later save does
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(path, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queue.Approve(draft.ID, ApprovedIDBase+1, time.Now()); err == nil {
+		t.Fatal("approval saved over a directory")
+	}
+	if _, err := queue.Add(nil, "cardiology", 0.5, time.Now()); err == nil {
+		t.Fatal("add saved over a directory")
+	}
+	if _, err := queue.Add(make([]content.Question, 2), "cardiology", 0.5, time.Now()); err == nil {
+		t.Fatal("add saved over a directory")
+	}
+
+	drafts := queue.List("")
+	if len(drafts) != 1 || drafts[0].Status != Pending || drafts[0].Question.ID != 0 {
+		t.Errorf("drafts after failed saves = %+v, want only the first, still pending", drafts)
+	}
+
+	// Once saves work again, numbering carries on where it left off
+	if err := os.RemoveAll(path); err != nil {
+		t.Fatal(err)
+	}
+	if added := queued(t, queue, "second"); added[0].ID != "2" {
+		t.Errorf("draft added after failed saves got ID %s, want 2", added[0].ID)
+	}
+}
@@ -0,0 +1,127 @@
+package authoring
+
+import (
+	"fmt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/grading"
+	"strings"
+	"unicode"
+)
+
+// NearDuplicateSimilarity is the word overlap (Jaccard similarity of the
+// texts' word sets) at which two questions count as near duplicates. Word
+// overlap rather than edit distance, because stems like "What does the suffix
+// '-algia' mean?" differ from each other by only a few characters.
+const NearDuplicateSimilarity = 0.8
+
+// Taxonomy is the set of tags generated questions may use: every tag in the
+// bank plus every tag the skill graph maps to a skill
+func Taxonomy(questions []content.Question, graph *content.SkillGraph) map[string]bool {
+	tags := make(map[string]bool)
+	for _, q := range questions {
+		for _, tag := range q.Metadata.Tags {
+			tags[tag] = true
+		}
+	}
+	if graph != nil {
+		for _, tag := range graph.Tags() {
+			tags[tag] = true
+		}
+	}
+	return tags
+}
+
+// Validate returns the problems that keep a generated multiple-choice question
+// out of the review queue; none means it can be reviewed. existing should
+// include the bank and any drafts already queued.
+func Validate(q content.Question, existing []content.Question, taxonomy map[string]bool) []string {
+	var problems []string
+	if q.QuestionType() != content.MultipleChoice {
+		problems = append(problems, fmt.Sprintf("unsupported question type %q", q.Type))
+	}
+	if q.Text == "" {
+		problems = append(problems, "no question text")
+	}
+	if q.Answer == "" {
+		problems = append(problems, "no answer")
+	}
+	if len(q.Options) < 2 {
+		problems = append(problems, "fewer than two options")
+	}
+
+	seen := make(map[string]bool, len(q.Options))
+	hasAnswer := false
+	for _, option := range q.Options {
+		key := grading.Normalize(option)
+		if seen[key] {
+			problems = append(problems, fmt.Sprintf("duplicate option %q", option))
+		}
+		seen[key] = true
+		if option == q.Answer {
+			hasAnswer = true
+		}
+	}
+	if q.Answer != "" && !hasAnswer {
+		problems = append(problems, "answer is not one of the options")
+	}
+
+	if len(q.Metadata.Tags) == 0 {
+		problems = append(problems, "no tags")
+	}
+	for _, tag := range q.Metadata.Tags {
+		if !taxonomy[tag] {
+			problems = append(problems, fmt.Sprintf("unknown tag %q", tag))
+		}
+	}
+	for _, issue := range content.ValidateFeedback([]content.Question{q}) {
+		if issue.Option == "" {
+			problems = append(problems, issue.Problem)
+		} else {
+			problems = append(problems, fmt.Sprintf("option %q: %s", issue.Option, issue.Problem))
+		}
+	}
+	if q.Metadata.Difficulty < 0 || q.Metadata.Difficulty > 1 {
+		problems = append(problems, "difficulty outside 0 to 1")
+	}
+
+	for i := range existing {
+		if nearDuplicate(q.Text, existing[i].Text) {
+			problems = append(problems, fmt.Sprintf("near duplicate of %q", existing[i].Text))
+		}
+	}
+	return problems
+}
+
+func nearDuplicate(a, b string) bool {
+	wordsA, wordsB := words(a), words(b)
+	union := len(wordsA)
+	shared := 0
+	for w := range wordsB {
+		if wordsA[w] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return true
+	}
+	return float64(shared)/float64(union) >= NearDuplicateSimilarity
+}
+
+// words returns the normalized words of s, ignoring punctuation
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, field := range strings.Fields(grading.Normalize(s)) {
+		word := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, field)
+		if word != "" {
+			set[word] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,156 @@
+package bkt
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Parameters for the worked examples below
+const l0, learn, slip, guess = 0.3, 0.1, 0.1, 0.2
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestUpdateGraded(t *testing.T) {
+	tests := []struct {
+		name    string
+		score   float64
+		want    float64
+		correct bool
+	}{
+		{"correct", 1, 0.6926829268292684, true},
+		{"incorrect", 0, 0.14576271186440679, false},
+		{"half credit", 0.5, 0.4192228193468375, false},
+		{"quarter credit", 0.25, 0.2824927656056222, false},
+		{"above 1 is correct", 1.5, 0.6926829268292684, true},
+		{"below 0 is incorrect", -1, 0.14576271186440679, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := InitializeBKTModel(l0, learn, slip, guess)
+			model.UpdateGraded(tt.score)
+			if got := model.GetCurrentKnowledge(); !near(got, tt.want) {
+				t.Errorf("P(L) = %v, want %v", got, tt.want)
+			}
+			if history := model.GetAnswerHistory(); len(history) != 1 || history[0] != tt.correct {
+				t.Errorf("answer history = %v, want [%v]", history, tt.correct)
+			}
+			if history := model.GetKnowledgeHistory(); len(history) != 1 || !near(history[0], tt.want) {
+				t.Errorf("knowledge history = %v", history)
+			}
+		})
+	}
+}
+
+func TestUpdateGradedIsContinuous(t *testing.T) {
+	at := func(score float64) float64 {
+		model := InitializeBKTModel(l0, learn, slip, guess)
+		model.UpdateGraded(score)
+		return model.GetCurrentKnowledge()
+	}
+	if math.Abs(at(0)-at(1e-9)) > 1e-6 || math.Abs(at(1)-at(1-1e-9)) > 1e-6 {
+		t.Errorf("jump at the ends: %v vs %v, %v vs %v", at(0), at(1e-9), at(1), at(1-1e-9))
+	}
+}
+
+// A wrong answer must never raise P(L) past 1, however likely a slip was
+func TestUpdateIncorrectStaysAProbability(t *testing.T) {
+	tests := []struct {
+		name        string
+		l0, t, s, g float64
+	}{
+		{"high prior, high slip", 0.99, 0.5, 0.3, 0.1},
+		{"certain", 1, 0.3, 0.2, 0.2},
+		{"no learning", 0.5, 0, 0.1, 0.2},
+		{"always learns", 0.2, 1, 0.1, 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := InitializeBKTModel(tt.l0, tt.t, tt.s, tt.g)
+			for i := 0; i < 20; i++ {
+				model.UpdateIncorrect()
+				if p := model.GetCurrentKnowledge(); p < 0 || p > 1 || math.IsNaN(p) {
+					t.Fatalf("P(L) = %v after %d wrong answers", p, i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateHinted(t *testing.T) {
+	tests := []struct {
+		name  string
+		score float64
+		help  float64
+		want  float64
+	}{
+		{"no help is graded", 1, 0, 0.6926829268292684},
+		{"half the answer given", 1, 0.5, 0.4521739130434783},
+		{"whole answer given", 1, 1, 0.3505154639175258},
+		{"help above 1 is capped", 1, 3, 0.3505154639175258},
+		{"wrong despite help counts as usual", 0, 0.5, 0.14576271186440679},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := InitializeBKTModel(l0, learn, slip, guess)
+			model.UpdateHinted(tt.score, tt.help)
+			if got := model.GetCurrentKnowledge(); !near(got, tt.want) {
+				t.Errorf("P(L) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateTimed(t *testing.T) {
+	params := DefaultLatencyParams
+	tests := []struct {
+		name         string
+		score        float64
+		latency      time.Duration
+		want         float64
+		rapidGuesses int
+	}{
+		{"unmeasured correct", 1, 0, 0.6926829268292684, 0},
+		{"fluent correct", 1, params.Fast / 2, 0.8147058823529412, 0},
+		{"ordinary correct", 1, (params.Fast + params.Slow) / 2, 0.6926829268292684, 0},
+		{"effortful correct", 1, params.Slow * 2, 0.5418181818181818, 0},
+		{"unmeasured wrong", 0, 0, 0.14576271186440679, 0},
+		{"considered wrong", 0, params.Slow, 0.14576271186440679, 0},
+		{"rapid guess", 0, params.RapidGuess / 2, 0.30482758620689654, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewLatencyBKT(l0, learn, slip, guess, params)
+			model.UpdateTimed(tt.score, 0, tt.latency)
+			if got := model.GetCurrentKnowledge(); !near(got, tt.want) {
+				t.Errorf("P(L) = %v, want %v", got, tt.want)
+			}
+			if model.RapidGuesses() != tt.rapidGuesses {
+				t.Errorf("rapid guesses = %d, want %d", model.RapidGuesses(), tt.rapidGuesses)
+			}
+		})
+	}
+}
+
+func TestRapidGuessesRaiseGuessRate(t *testing.T) {
+	params := DefaultLatencyParams
+	model := NewLatencyBKT(l0, learn, slip, guess, params)
+	for i := 1; i <= 20; i++ {
+		model.UpdateTimed(0, 0, params.RapidGuess/2)
+		want := math.Min(guess+float64(i)*params.GuessStep, params.MaxGuess)
+		if !near(model.Guess(), want) {
+			t.Fatalf("guess after %d rapid guesses = %v, want %v", i, model.Guess(), want)
+		}
+	}
+
+	// A guessing learner's correct answers are weaker evidence than a fresh learner's
+	guessing := model.GetCurrentKnowledge()
+	model.UpdateTimed(1, 0, 0)
+	fresh := NewLatencyBKT(guessing, learn, slip, guess, params)
+	fresh.UpdateTimed(1, 0, 0)
+	if model.GetCurrentKnowledge() >= fresh.GetCurrentKnowledge() {
+		t.Errorf("correct answer after rapid guesses gave P(L) %v, not below %v", model.GetCurrentKnowledge(), fresh.GetCurrentKnowledge())
+	}
+}
@@ -0,0 +1,59 @@
+package bkt
+
+import "math"
+
+// Parameter fitting by grid search: every combination of the grid values is
+// scored by the log-loss of its next-answer predictions over the training
+// sequences, and the best is returned. Coarse, but deterministic and free of
+// the local optima EM runs into with only a few short sequences.
+
+var (
+	fitL0Grid = []float64{0.01, 0.05, 0.1, 0.2, 0.3, 0.5, 0.7}
+	fitTGrid  = []float64{0.01, 0.05, 0.1, 0.15, 0.2, 0.3, 0.4}
+	fitSGrid  = []float64{0.01, 0.05, 0.1, 0.15, 0.2, 0.3}
+	fitGGrid  = []float64{0.05, 0.1, 0.15, 0.2, 0.25, 0.3}
+)
+
+// Fit returns the grid parameters with the lowest log-loss on sequences
+// (each one learner's answers, oldest first). Guess + slip is kept below 1 so
+// a correct answer is always evidence of knowledge.
+func Fit(sequences [][]bool) (l0, t, s, g float64) {
+	best := math.Inf(1)
+	l0, t, s, g = 0.02, 0.1, 0.05, 0.2 // Session defaults if there is no data
+	for _, cl0 := range fitL0Grid {
+		for _, ct := range fitTGrid {
+			for _, cs := range fitSGrid {
+				for _, cg := range fitGGrid {
+					if cs+cg >= 1 {
+						continue
+					}
+					loss := logLoss(sequences, cl0, ct, cs, cg)
+					if loss < best {
+						best = loss
+						l0, t, s, g = cl0, ct, cs, cg
+					}
+				}
+			}
+		}
+	}
+	return l0, t, s, g
+}
+
+func logLoss(sequences [][]bool, l0, t, s, g float64) float64 {
+	const eps = 1e-9
+	var loss float64
+	for _, seq := range sequences {
+		model := InitializeBKTModel(l0, t, s, g)
+		for _, correct := range seq {
+			p := math.Min(1-eps, math.Max(eps, model.PredictCorrect()))
+			if correct {
+				loss -= math.Log(p)
+				model.UpdateCorrect()
+			} else {
+				loss -= math.Log(1 - p)
+				model.UpdateIncorrect()
+			}
+		}
+	}
+	return loss
+}
@@ -8,6 +8,7 @@ type BKTModel struct {
 	G float64
 
 	answerHistory []bool
+	scoreHistory []float64
 	currentKnowledge float64
 	knowledgeHistory []float64
 }
@@ -35,6 +36,11 @@ func (bkt *BKTModel) GetAnswerHistory() []bool {
 	return bkt.answerHistory
 }
 
+// GetScoreHistory returns the credit given for each answer, from 0 to 1
+func (bkt *BKTModel) GetScoreHistory() []float64 {
+	return bkt.scoreHistory
+}
+
 func (bkt *BKTModel) GetParameters() (l0, t, s, g float64) {
 	return bkt.L0, bkt.T, bkt.S, bkt.G
 }
@@ -49,9 +55,10 @@ func (bkt *BKTModel) UpdateIncorrect(){
 	var actual = pLn/(pLn+pLd)
 	//probablility they know it = probability they migh thave slipped plus probability they didn't know it * probability they learned it
 	//update current knowledge for next question
-	bkt.currentKnowledge = actual + ((1-bkt.G)*(bkt.T))
+	bkt.currentKnowledge = actual + ((1-actual)*(bkt.T))
 	bkt.knowledgeHistory = append(bkt.knowledgeHistory, bkt.currentKnowledge)
 	bkt.answerHistory = append(bkt.answerHistory, false)
+	bkt.scoreHistory = append(bkt.scoreHistory, 0)
 }
 
 func (bkt *BKTModel) UpdateCorrect(){
@@ -66,5 +73,63 @@ func (bkt *BKTModel) UpdateCorrect(){
 	bkt.currentKnowledge = actual + ((1-actual)*(bkt.T))
 	bkt.knowledgeHistory = append(bkt.knowledgeHistory, bkt.currentKnowledge)
 	bkt.answerHistory = append(bkt.answerHistory, true)
+	bkt.scoreHistory = append(bkt.scoreHistory, 1)
+}
 
-}
\ No newline at end of file
+// UpdateGraded updates knowledge from a partial-credit answer. A score of 1 or
+// 0 is an ordinary correct or incorrect update; in between, the posterior is the
+// score-weighted mix of the correct and incorrect posteriors (soft evidence),
+// followed by the usual learning transition. Partial answers count as incorrect
+// in the answer history.
+func (bkt *BKTModel) UpdateGraded(score float64){
+	if score >= 1 {
+		bkt.UpdateCorrect()
+		return
+	}
+	if score <= 0 {
+		bkt.UpdateIncorrect()
+		return
+	}
+
+	known := bkt.currentKnowledge
+	//probability they knew it given a correct answer, and given an incorrect one
+	var ifCorrect = known*(1-bkt.S) / (known*(1-bkt.S) + (1-known)*bkt.G)
+	var ifIncorrect = known*bkt.S / (known*bkt.S + (1-known)*(1-bkt.G))
+	var actual = score*ifCorrect + (1-score)*ifIncorrect
+
+	bkt.currentKnowledge = actual + ((1-actual)*(bkt.T))
+	bkt.knowledgeHistory = append(bkt.knowledgeHistory, bkt.currentKnowledge)
+	bkt.answerHistory = append(bkt.answerHistory, false)
+	bkt.scoreHistory = append(bkt.scoreHistory, score)
+}
+
+// UpdateHinted updates knowledge from an answer given after hints. help, from
+// 0 to 1, is how much the hints gave away: it raises the chance of answering
+// correctly without knowing the skill from G toward 1, so a hinted correct
+// answer is weaker evidence than an unaided one. Wrong answers count as usual.
+// With no help it is UpdateGraded.
+func (bkt *BKTModel) UpdateHinted(score, help float64){
+	if help <= 0 {
+		bkt.UpdateGraded(score)
+		return
+	}
+	score = min(max(score, 0), 1)
+	//guess rate with the hints' help
+	var guess = bkt.G + (1-bkt.G)*min(help, 1)
+
+	known := bkt.currentKnowledge
+	var ifCorrect = known*(1-bkt.S) / (known*(1-bkt.S) + (1-known)*guess)
+	var ifIncorrect = known*bkt.S / (known*bkt.S + (1-known)*(1-bkt.G))
+	var actual = score*ifCorrect + (1-score)*ifIncorrect
+
+	bkt.currentKnowledge = actual + ((1-actual)*(bkt.T))
+	bkt.knowledgeHistory = append(bkt.knowledgeHistory, bkt.currentKnowledge)
+	bkt.answerHistory = append(bkt.answerHistory, score >= 1)
+	bkt.scoreHistory = append(bkt.scoreHistory, score)
+}
+
+// PredictCorrect is the probability the next answer is correct given current knowledge:
+// knew it and didn't slip, or didn't know it and guessed
+func (bkt *BKTModel) PredictCorrect() float64 {
+	return bkt.currentKnowledge*(1-bkt.S) + (1-bkt.currentKnowledge)*bkt.G
+}
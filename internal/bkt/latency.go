@@ -0,0 +1,91 @@
+package bkt
+
+import "time"
+
+// LatencyParams shape how response time changes the evidence an answer gives.
+// Fast correct answers suggest fluent recall, slow ones effortful or looked-up
+// answers, and very fast wrong answers suggest the learner is guessing.
+type LatencyParams struct {
+	Fast       time.Duration // Correct answers quicker than this count as fluent
+	Slow       time.Duration // Correct answers slower than this count as effortful
+	RapidGuess time.Duration // Wrong answers quicker than this count as rapid guesses
+	GuessStep  float64       // How much each rapid guess raises the learner's estimated guess rate
+	MaxGuess   float64       // Ceiling for the estimated guess rate
+}
+
+var DefaultLatencyParams = LatencyParams{
+	Fast:       5 * time.Second,
+	Slow:       30 * time.Second,
+	RapidGuess: 2 * time.Second,
+	GuessStep:  0.05,
+	MaxGuess:   0.5,
+}
+
+const (
+	fluentGuessFactor = 0.5  // A fluent correct answer is half as likely to be a guess
+	effortfulHelp     = 0.25 // A slow correct answer is treated like a quarter of the answer given away
+	rapidGuessSlip    = 0.5  // A rapid wrong answer is half explained by not trying
+)
+
+// LatencyBKT is BKT that also weighs how long each answer took. The learner's
+// guess rate starts at G and rises with every rapid guess, so later correct
+// answers from a guessing learner count for less.
+type LatencyBKT struct {
+	*BKTModel
+	params       LatencyParams
+	guess        float64
+	rapidGuesses int
+}
+
+func NewLatencyBKT(l0, t, s, g float64, params LatencyParams) *LatencyBKT {
+	return &LatencyBKT{
+		BKTModel: InitializeBKTModel(l0, t, s, g),
+		params:   params,
+		guess:    g,
+	}
+}
+
+// Guess is the learner's current estimated guess rate
+func (lb *LatencyBKT) Guess() float64 {
+	return lb.guess
+}
+
+// RapidGuesses counts the wrong answers that came too fast to be considered
+func (lb *LatencyBKT) RapidGuesses() int {
+	return lb.rapidGuesses
+}
+
+// UpdateTimed updates knowledge from an answer that took latency, with help
+// from hints as in UpdateHinted. A latency of 0 means it wasn't measured, and
+// only the learner's guess estimate applies.
+func (lb *LatencyBKT) UpdateTimed(score, help float64, latency time.Duration) {
+	score = min(max(score, 0), 1)
+	guess, slip := lb.guess, lb.S
+	missIfUnknown := 1 - lb.guess // Before this answer changes the guess estimate
+
+	switch {
+	case latency <= 0:
+	case score > 0 && latency < lb.params.Fast:
+		guess *= fluentGuessFactor
+	case score > 0 && latency > lb.params.Slow:
+		guess += (1 - guess) * effortfulHelp
+	case score == 0 && latency < lb.params.RapidGuess:
+		slip += (1 - slip) * rapidGuessSlip
+		lb.rapidGuesses++
+		lb.guess = min(lb.guess+lb.params.GuessStep, max(lb.params.MaxGuess, lb.G))
+	}
+	if help > 0 {
+		guess += (1 - guess) * min(help, 1)
+	}
+
+	known := lb.currentKnowledge
+	//probability they knew it given a correct answer, and given an incorrect one
+	var ifCorrect = known * (1 - lb.S) / (known*(1-lb.S) + (1-known)*guess)
+	var ifIncorrect = known * slip / (known*slip + (1-known)*missIfUnknown)
+	var actual = score*ifCorrect + (1-score)*ifIncorrect
+
+	lb.currentKnowledge = actual + ((1 - actual) * (lb.T))
+	lb.knowledgeHistory = append(lb.knowledgeHistory, lb.currentKnowledge)
+	lb.answerHistory = append(lb.answerHistory, score >= 1)
+	lb.scoreHistory = append(lb.scoreHistory, score)
+}
@@ -0,0 +1,26 @@
+package content
+
+// ClientQuestion is what learners are shown of a question. It leaves out
+// everything that would give the answer away (answers, accepted spellings,
+// rubric, per-option feedback and hints), so serve this, never a Question.
+// Field names match Question's, which clients already read.
+type ClientQuestion struct {
+	ID         int          `json:"ID"`
+	Type       QuestionType `json:"Type"`
+	Text       string       `json:"Text"`
+	Options    []string     `json:"Options,omitempty"`
+	Difficulty float64      `json:"Difficulty"`
+	Tags       []string     `json:"Tags,omitempty"`
+}
+
+// ForClient returns the learner-facing view of the question
+func (q *Question) ForClient() ClientQuestion {
+	return ClientQuestion{
+		ID:         q.ID,
+		Type:       q.QuestionType(),
+		Text:       q.Text,
+		Options:    q.Options,
+		Difficulty: q.Metadata.Difficulty,
+		Tags:       q.Metadata.Tags,
+	}
+}
@@ -0,0 +1,106 @@
+package content
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func clientJSON(t *testing.T, q *Question) (string, map[string]any) {
+	t.Helper()
+	data, err := json.Marshal(q.ForClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	return string(data), fields
+}
+
+// The keys of OptionFeedback are the wrong options, so sending them would
+// reveal the right one by elimination
+func TestForClientHidesOptionFeedback(t *testing.T) {
+	questions, err := NewStaticBank().GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checked := 0
+	for i := range questions {
+		q := &questions[i]
+		if len(q.OptionFeedback) == 0 {
+			continue
+		}
+		checked++
+		raw, fields := clientJSON(t, q)
+		if _, ok := fields["OptionFeedback"]; ok {
+			t.Errorf("question %d: OptionFeedback served", q.ID)
+		}
+		for option, feedback := range q.OptionFeedback {
+			if strings.Contains(raw, feedback) {
+				t.Errorf("question %d: feedback for %q served", q.ID, option)
+			}
+		}
+	}
+	if checked == 0 {
+		t.Fatal("no static questions have option feedback")
+	}
+}
+
+// Typed answers are graded against Answer, Answers and Synonyms, so none of
+// them may be served for questions without options
+func TestForClientHidesAcceptedAnswers(t *testing.T) {
+	questions, err := NewStaticBank().GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range questions {
+		q := &questions[i]
+		raw, fields := clientJSON(t, q)
+		for _, key := range []string{"Answer", "Answers", "Synonyms", "MaxEdits"} {
+			if _, ok := fields[key]; ok {
+				t.Errorf("question %d: %s served", q.ID, key)
+			}
+		}
+		if len(q.Options) > 0 {
+			continue // The answers are among the options by design
+		}
+		accepted := append([]string{q.Answer}, q.Answers...)
+		for _, spellings := range q.Synonyms {
+			accepted = append(accepted, spellings...)
+		}
+		for _, answer := range accepted {
+			if answer != "" && strings.Contains(raw, answer) {
+				t.Errorf("question %d: accepted answer %q served in %s", q.ID, answer, raw)
+			}
+		}
+	}
+}
+
+func TestForClientHidesRubric(t *testing.T) {
+	questions, err := NewStaticBank().GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checked := 0
+	for i := range questions {
+		q := &questions[i]
+		if len(q.Rubric) == 0 {
+			continue
+		}
+		checked++
+		raw, fields := clientJSON(t, q)
+		if _, ok := fields["Rubric"]; ok {
+			t.Errorf("question %d: Rubric served", q.ID)
+		}
+		for _, criterion := range q.Rubric {
+			if strings.Contains(raw, criterion.Description) {
+				t.Errorf("question %d: criterion %q served", q.ID, criterion.ID)
+			}
+		}
+	}
+	if checked == 0 {
+		t.Fatal("no static questions have a rubric")
+	}
+}
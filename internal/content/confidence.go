@@ -0,0 +1,36 @@
+package content
+
+// Learners may rate how sure they are of each answer, from MinConfidence
+// (guessing) to MaxConfidence (certain). 0 means the answer wasn't rated.
+const (
+	MinConfidence = 1
+	MaxConfidence = 4
+	ConfidentFrom = 3 // Ratings from here up count as confident, below as unsure
+)
+
+// ValidConfidence reports whether c is a rating or 0 for unrated
+func ValidConfidence(c int) bool {
+	return c == 0 || (c >= MinConfidence && c <= MaxConfidence)
+}
+
+// ConfidenceProbability reads a rating as the chance the learner gave
+// themselves of being right: 1 is a guess among four options, 4 is certain
+func ConfidenceProbability(c int) float64 {
+	return float64(c) / MaxConfidence
+}
+
+// MisconceptionBelow is the credit under which a confident wrong answer counts
+// as a misconception. Near misses and partly right answers earn more and are
+// slips in recall rather than wrong beliefs.
+const MisconceptionBelow = 0.5
+
+// LikelyMisconception reports a wrong answer the learner was confident in,
+// which points to a belief held rather than a gap in knowledge
+func (r AnswerRecord) LikelyMisconception() bool {
+	return !r.Correct && r.Score < MisconceptionBelow && r.Confidence >= ConfidentFrom
+}
+
+// Underconfident reports a correct answer the learner rated as unsure
+func (r AnswerRecord) Underconfident() bool {
+	return r.Correct && r.Confidence > 0 && r.Confidence < ConfidentFrom
+}
@@ -0,0 +1,26 @@
+package content
+
+import "testing"
+
+func TestLikelyMisconception(t *testing.T) {
+	tests := []struct {
+		name   string
+		record AnswerRecord
+		want   bool
+	}{
+		{"confident and wrong", AnswerRecord{Correct: false, Score: 0, Confidence: 4}, true},
+		{"confident, little credit", AnswerRecord{Correct: false, Score: 0.25, Confidence: ConfidentFrom}, true},
+		{"confident near miss", AnswerRecord{Correct: false, Score: 0.9, Confidence: 4}, false},
+		{"confident, half right", AnswerRecord{Correct: false, Score: MisconceptionBelow, Confidence: 4}, false},
+		{"unsure and wrong", AnswerRecord{Correct: false, Score: 0, Confidence: ConfidentFrom - 1}, false},
+		{"unrated and wrong", AnswerRecord{Correct: false, Score: 0}, false},
+		{"confident and right", AnswerRecord{Correct: true, Score: 1, Confidence: 4}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.record.LikelyMisconception(); got != tt.want {
+				t.Errorf("LikelyMisconception() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
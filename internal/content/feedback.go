@@ -1 +1,75 @@
-package content
\ No newline at end of file
+package content
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ResolveFeedback returns the feedback for the option a learner chose. A wrong
+// option with its own explanation gets that, followed by the question's general
+// feedback; anything else gets the general feedback alone.
+func ResolveFeedback(q *Question, chosen string) string {
+	if chosen == q.Answer {
+		return q.Feedback
+	}
+	optionFeedback, ok := q.OptionFeedback[chosen]
+	if !ok || optionFeedback == "" {
+		return q.Feedback
+	}
+	if q.Feedback == "" {
+		return optionFeedback
+	}
+	return optionFeedback + " " + q.Feedback
+}
+
+// FeedbackIssue is a gap or mistake in a question's feedback
+type FeedbackIssue struct {
+	QuestionID int
+	Option     string // Empty for issues with the question's general feedback
+	Problem    string
+}
+
+func (i FeedbackIssue) String() string {
+	if i.Option == "" {
+		return fmt.Sprintf("question %d: %s", i.QuestionID, i.Problem)
+	}
+	return fmt.Sprintf("question %d, option %q: %s", i.QuestionID, i.Option, i.Problem)
+}
+
+// ValidateFeedback flags questions without general feedback, wrong options of
+// multiple-choice questions without their own feedback, and option feedback for
+// options the question doesn't have (usually a typo in the key).
+func ValidateFeedback(questions []Question) []FeedbackIssue {
+	var issues []FeedbackIssue
+	for _, q := range questions {
+		if strings.TrimSpace(q.Feedback) == "" {
+			issues = append(issues, FeedbackIssue{QuestionID: q.ID, Problem: "no general feedback"})
+		}
+
+		options := make(map[string]bool, len(q.Options))
+		for _, option := range q.Options {
+			options[option] = true
+			if option == q.Answer || q.QuestionType() != MultipleChoice {
+				continue // The general feedback explains the correct answer
+			}
+			if strings.TrimSpace(q.OptionFeedback[option]) == "" {
+				issues = append(issues, FeedbackIssue{QuestionID: q.ID, Option: option, Problem: "no option feedback"})
+			}
+		}
+
+		keyed := make([]string, 0, len(q.OptionFeedback))
+		for option := range q.OptionFeedback {
+			keyed = append(keyed, option)
+		}
+		sort.Strings(keyed)
+		for _, option := range keyed {
+			if option == q.Answer {
+				issues = append(issues, FeedbackIssue{QuestionID: q.ID, Option: option, Problem: "option feedback for the correct answer is never shown"})
+			} else if !options[option] {
+				issues = append(issues, FeedbackIssue{QuestionID: q.ID, Option: option, Problem: "option feedback for an option the question doesn't have"})
+			}
+		}
+	}
+	return issues
+}
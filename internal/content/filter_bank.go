@@ -0,0 +1,42 @@
+package content
+
+// FilteredBank offers only some of a bank's questions for selection. Lookups by
+// ID still reach every question, so answers to excluded ones are graded as usual.
+type FilteredBank struct {
+	bank    QuestionBank
+	include func(q *Question) bool
+}
+
+func NewFilteredBank(bank QuestionBank, include func(q *Question) bool) *FilteredBank {
+	return &FilteredBank{bank: bank, include: include}
+}
+
+// WithoutTypes offers every question except those of the given types
+func WithoutTypes(bank QuestionBank, types ...QuestionType) *FilteredBank {
+	return NewFilteredBank(bank, func(q *Question) bool {
+		for _, t := range types {
+			if q.QuestionType() == t {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (fb *FilteredBank) GetAll() ([]Question, error) {
+	all, err := fb.bank.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	questions := make([]Question, 0, len(all))
+	for i := range all {
+		if fb.include(&all[i]) {
+			questions = append(questions, all[i])
+		}
+	}
+	return questions, nil
+}
+
+func (fb *FilteredBank) GetQuestionByID(id int) (*Question, error) {
+	return fb.bank.GetQuestionByID(id)
+}
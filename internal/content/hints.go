@@ -0,0 +1,146 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MaxHints caps how many hint tiers a question may have
+const MaxHints = 3
+
+// MaxHintHelp is how much of the answer a question's full set of hints is
+// taken to give away. Even with every hint shown, a correct answer says a
+// little about what the learner knows.
+const MaxHintHelp = 0.8
+
+// ErrNoHints means a question has no hints and none could be generated
+var ErrNoHints = errors.New("no hints for this question")
+
+// HintGenerator writes tiered hints for questions that have none authored
+type HintGenerator interface {
+	GenerateHints(ctx context.Context, q *Question, count int) ([]string, error)
+}
+
+// HintHelp is how much revealing used of a question's tiers hints helped,
+// growing with each tier up to MaxHintHelp
+func HintHelp(used, tiers int) float64 {
+	if used <= 0 || tiers <= 0 {
+		return 0
+	}
+	return MaxHintHelp * float64(min(used, tiers)) / float64(tiers)
+}
+
+// HintSource serves a question's authored hints, generating hints for
+// questions without any. Generated hints are kept, so every learner sees the
+// same hints for a question. Generation runs outside the lock, once per
+// question however many learners ask at the same time. It isn't cancelled when
+// the learner who started it gives up, so the others still get hints; the
+// generator is expected to bound it with a timeout.
+type HintSource struct {
+	generator HintGenerator // nil serves authored hints only
+
+	mu        sync.Mutex
+	generated map[int][]string
+	pending   map[int]*hintCall // Generations in flight, by question ID
+}
+
+// hintCall is one generation that every concurrent request for the question waits on
+type hintCall struct {
+	done  chan struct{}
+	hints []string
+	err   error
+}
+
+func NewHintSource(generator HintGenerator) *HintSource {
+	return &HintSource{
+		generator: generator,
+		generated: make(map[int][]string),
+		pending:   make(map[int]*hintCall),
+	}
+}
+
+// Available returns how many hints the question offers, counting hints that
+// would be generated on first request
+func (hs *HintSource) Available(q *Question) int {
+	if len(q.Hints) > 0 {
+		return len(q.Hints)
+	}
+	if hs.generator == nil {
+		return 0
+	}
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if hints, ok := hs.generated[q.ID]; ok {
+		return len(hints)
+	}
+	return MaxHints
+}
+
+// Hints returns the question's hint tiers, or ErrNoHints. It stops waiting for
+// a generation when ctx is done.
+func (hs *HintSource) Hints(ctx context.Context, q *Question) ([]string, error) {
+	if len(q.Hints) > 0 {
+		return q.Hints, nil
+	}
+	if hs.generator == nil {
+		return nil, fmt.Errorf("%w: question %d", ErrNoHints, q.ID)
+	}
+
+	hs.mu.Lock()
+	if hints, ok := hs.generated[q.ID]; ok {
+		hs.mu.Unlock()
+		return hints, nil
+	}
+	call, inFlight := hs.pending[q.ID]
+	if !inFlight {
+		call = &hintCall{done: make(chan struct{})}
+		hs.pending[q.ID] = call
+		go hs.run(context.WithoutCancel(ctx), q, call)
+	}
+	hs.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.hints, call.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("question %d: waiting for hints: %w", q.ID, ctx.Err())
+	}
+}
+
+// run generates hints for call and releases everyone waiting on it
+func (hs *HintSource) run(ctx context.Context, q *Question, call *hintCall) {
+	call.hints, call.err = hs.generate(ctx, q)
+	hs.mu.Lock()
+	if call.err == nil {
+		hs.generated[q.ID] = call.hints
+	}
+	delete(hs.pending, q.ID) // Failures aren't kept, so a later request tries again
+	hs.mu.Unlock()
+	close(call.done)
+}
+
+func (hs *HintSource) generate(ctx context.Context, q *Question) ([]string, error) {
+	hints, err := hs.generator.GenerateHints(ctx, q, MaxHints)
+	if err != nil {
+		return nil, fmt.Errorf("question %d: hint generation failed: %w", q.ID, err)
+	}
+	if err := validateHints(hints); err != nil || len(hints) == 0 {
+		return nil, fmt.Errorf("%w: question %d: generated hints were unusable", ErrNoHints, q.ID)
+	}
+	return hints, nil
+}
+
+func validateHints(hints []string) error {
+	if len(hints) > MaxHints {
+		return fmt.Errorf("more than %d hints", MaxHints)
+	}
+	for _, hint := range hints {
+		if strings.TrimSpace(hint) == "" {
+			return errors.New("empty hint")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingGenerator holds every generation until release is closed
+type blockingGenerator struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+	err     error
+}
+
+func (g *blockingGenerator) GenerateHints(ctx context.Context, q *Question, count int) ([]string, error) {
+	if g.calls.Add(1) == 1 {
+		close(g.started)
+	}
+	<-g.release
+	if g.err != nil {
+		return nil, g.err
+	}
+	return []string{"nudge", "stronger", "nearly the answer"}[:count], nil
+}
+
+func TestHintSourceDoesNotBlockWhileGenerating(t *testing.T) {
+	gen := &blockingGenerator{started: make(chan struct{}), release: make(chan struct{})}
+	hs := NewHintSource(gen)
+	slow := &Question{ID: 1}
+
+	var wg sync.WaitGroup
+	results := make([][]string, 4)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = hs.Hints(context.Background(), slow)
+		}()
+	}
+	<-gen.started
+
+	// Serving questions must not wait on another question's generation
+	available := make(chan int)
+	go func() { available <- hs.Available(&Question{ID: 2}) }()
+	select {
+	case n := <-available:
+		if n != MaxHints {
+			t.Errorf("Available = %d, want %d", n, MaxHints)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Available blocked while hints were being generated")
+	}
+
+	close(gen.release)
+	wg.Wait()
+	if calls := gen.calls.Load(); calls != 1 {
+		t.Errorf("generated %d times for concurrent requests, want once", calls)
+	}
+	for i, hints := range results {
+		if len(hints) != MaxHints {
+			t.Errorf("request %d got %v", i, hints)
+		}
+	}
+	if n := hs.Available(slow); n != MaxHints {
+		t.Errorf("Available after generation = %d, want %d", n, MaxHints)
+	}
+}
+
+func TestHintSourceRetriesFailedGeneration(t *testing.T) {
+	gen := &blockingGenerator{started: make(chan struct{}), release: make(chan struct{}), err: errors.New("overloaded")}
+	close(gen.release)
+	hs := NewHintSource(gen)
+	q := &Question{ID: 3}
+
+	if _, err := hs.Hints(context.Background(), q); err == nil {
+		t.Fatal("Hints succeeded with a failing generator")
+	}
+	gen.err = nil
+	hints, err := hs.Hints(context.Background(), q)
+	if err != nil || len(hints) != MaxHints {
+		t.Fatalf("retry got %v, %v", hints, err)
+	}
+	if calls := gen.calls.Load(); calls != 2 {
+		t.Errorf("generator called %d times, want 2", calls)
+	}
+}
+
+func TestHintSourceServesAuthoredHints(t *testing.T) {
+	gen := &blockingGenerator{started: make(chan struct{}), release: make(chan struct{})}
+	hs := NewHintSource(gen)
+	q := &Question{ID: 4, Hints: []string{"authored"}}
+	if hints, err := hs.Hints(context.Background(), q); err != nil || len(hints) != 1 || hints[0] != "authored" {
+		t.Errorf("got %v, %v", hints, err)
+	}
+	if gen.calls.Load() != 0 {
+		t.Error("generated hints for a question with authored hints")
+	}
+	if _, err := NewHintSource(nil).Hints(context.Background(), &Question{ID: 5}); !errors.Is(err, ErrNoHints) {
+		t.Errorf("without a generator got %v, want ErrNoHints", err)
+	}
+}
+
+func TestHintSourceWaitersStopWithTheirContext(t *testing.T) {
+	gen := &blockingGenerator{started: make(chan struct{}), release: make(chan struct{})}
+	hs := NewHintSource(gen)
+	q := &Question{ID: 6}
+
+	// The learner who starts generation gives up, and so does one waiting on it
+	starter, cancel := context.WithCancel(context.Background())
+	waiter, cancelWaiter := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelWaiter()
+	errs := make(chan error, 2)
+	go func() {
+		_, err := hs.Hints(starter, q)
+		errs <- err
+	}()
+	<-gen.started
+	go func() {
+		_, err := hs.Hints(waiter, q)
+		errs <- err
+	}()
+	cancel()
+
+	for range 2 {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("err = %v, want the caller's context error", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Hints kept waiting after the caller's context was done")
+		}
+	}
+
+	// Generation carries on for everyone else
+	got := make(chan []string)
+	go func() {
+		hints, _ := hs.Hints(context.Background(), q)
+		got <- hints
+	}()
+	close(gen.release)
+	if hints := <-got; len(hints) != MaxHints {
+		t.Errorf("later request got %v", hints)
+	}
+	if calls := gen.calls.Load(); calls != 1 {
+		t.Errorf("generated %d times, want once", calls)
+	}
+}
@@ -0,0 +1,159 @@
+package content
+
+import "fmt"
+
+// WordPartKind is the role a word part plays in a term
+type WordPartKind string
+
+const (
+	Root   WordPartKind = "root"
+	Prefix WordPartKind = "prefix"
+	Suffix WordPartKind = "suffix"
+)
+
+// WordPart is one entry of the lexicon. Roots are written as combining forms
+// ("cardi/o"), prefixes end and suffixes start with a hyphen.
+type WordPart struct {
+	Form       string
+	Kind       WordPartKind
+	Meaning    string
+	BodySystem string // Empty for parts not tied to one system, e.g. most prefixes and suffixes
+}
+
+// Term is a real medical term built from lexicon parts, in order
+type Term struct {
+	Word    string
+	Parts   []string // Forms of the parts, e.g. {"hepat/o", "-itis"}
+	Meaning string
+}
+
+// Lexicon is the word parts and terms templated questions are built from
+type Lexicon struct {
+	Parts []WordPart
+	Terms []Term
+}
+
+// Validate checks that forms are unique and every term is built from known parts
+func (l *Lexicon) Validate() error {
+	forms := make(map[string]bool, len(l.Parts))
+	for _, part := range l.Parts {
+		if forms[part.Form] {
+			return fmt.Errorf("duplicate word part %q", part.Form)
+		}
+		forms[part.Form] = true
+	}
+	for _, term := range l.Terms {
+		if len(term.Parts) < 2 {
+			return fmt.Errorf("term %q has fewer than two parts", term.Word)
+		}
+		for _, form := range term.Parts {
+			if !forms[form] {
+				return fmt.Errorf("term %q uses unknown word part %q", term.Word, form)
+			}
+		}
+	}
+	return nil
+}
+
+// Part looks up a word part by form
+func (l *Lexicon) Part(form string) (WordPart, bool) {
+	for _, part := range l.Parts {
+		if part.Form == form {
+			return part, true
+		}
+	}
+	return WordPart{}, false
+}
+
+// MedicalLexicon returns the word parts and terms of the medical terminology course
+func MedicalLexicon() *Lexicon {
+	return &Lexicon{Parts: medicalWordParts, Terms: medicalTerms}
+}
+
+var medicalWordParts = []WordPart{
+	{Form: "dermat/o", Kind: Root, Meaning: "skin", BodySystem: "integumentary"},
+	{Form: "cardi/o", Kind: Root, Meaning: "heart", BodySystem: "cardiovascular"},
+	{Form: "angi/o", Kind: Root, Meaning: "vessel", BodySystem: "cardiovascular"},
+	{Form: "hemat/o", Kind: Root, Meaning: "blood", BodySystem: "cardiovascular"},
+	{Form: "gastr/o", Kind: Root, Meaning: "stomach", BodySystem: "digestive"},
+	{Form: "enter/o", Kind: Root, Meaning: "small intestine", BodySystem: "digestive"},
+	{Form: "hepat/o", Kind: Root, Meaning: "liver", BodySystem: "digestive"},
+	{Form: "col/o", Kind: Root, Meaning: "colon", BodySystem: "digestive"},
+	{Form: "nephr/o", Kind: Root, Meaning: "kidney", BodySystem: "urinary"},
+	{Form: "cyst/o", Kind: Root, Meaning: "bladder", BodySystem: "urinary"},
+	{Form: "neur/o", Kind: Root, Meaning: "nerve", BodySystem: "nervous"},
+	{Form: "encephal/o", Kind: Root, Meaning: "brain", BodySystem: "nervous"},
+	{Form: "oste/o", Kind: Root, Meaning: "bone", BodySystem: "musculoskeletal"},
+	{Form: "arthr/o", Kind: Root, Meaning: "joint", BodySystem: "musculoskeletal"},
+	{Form: "my/o", Kind: Root, Meaning: "muscle", BodySystem: "musculoskeletal"},
+	{Form: "pneumon/o", Kind: Root, Meaning: "lung", BodySystem: "respiratory"},
+	{Form: "rhin/o", Kind: Root, Meaning: "nose", BodySystem: "respiratory"},
+	{Form: "ot/o", Kind: Root, Meaning: "ear", BodySystem: "sensory"},
+	{Form: "ophthalm/o", Kind: Root, Meaning: "eye", BodySystem: "sensory"},
+
+	{Form: "hyper-", Kind: Prefix, Meaning: "excessive, above normal"},
+	{Form: "hypo-", Kind: Prefix, Meaning: "below normal"},
+	{Form: "peri-", Kind: Prefix, Meaning: "around"},
+	{Form: "endo-", Kind: Prefix, Meaning: "within"},
+	{Form: "poly-", Kind: Prefix, Meaning: "many"},
+	{Form: "brady-", Kind: Prefix, Meaning: "slow"},
+	{Form: "tachy-", Kind: Prefix, Meaning: "fast"},
+	{Form: "inter-", Kind: Prefix, Meaning: "between"},
+
+	{Form: "-itis", Kind: Suffix, Meaning: "inflammation"},
+	{Form: "-logy", Kind: Suffix, Meaning: "study of"},
+	{Form: "-ectomy", Kind: Suffix, Meaning: "surgical removal"},
+	{Form: "-otomy", Kind: Suffix, Meaning: "incision into"},
+	{Form: "-plasty", Kind: Suffix, Meaning: "surgical repair"},
+	{Form: "-algia", Kind: Suffix, Meaning: "pain"},
+	{Form: "-megaly", Kind: Suffix, Meaning: "enlargement"},
+	{Form: "-osis", Kind: Suffix, Meaning: "abnormal condition"},
+	{Form: "-scopy", Kind: Suffix, Meaning: "visual examination"},
+	{Form: "-oma", Kind: Suffix, Meaning: "tumor"},
+	{Form: "-pathy", Kind: Suffix, Meaning: "disease"},
+}
+
+var medicalTerms = []Term{
+	{Word: "dermatitis", Parts: []string{"dermat/o", "-itis"}, Meaning: "inflammation of the skin"},
+	{Word: "dermatology", Parts: []string{"dermat/o", "-logy"}, Meaning: "study of the skin"},
+	{Word: "cardiology", Parts: []string{"cardi/o", "-logy"}, Meaning: "study of the heart"},
+	{Word: "cardiomegaly", Parts: []string{"cardi/o", "-megaly"}, Meaning: "enlargement of the heart"},
+	{Word: "cardiomyopathy", Parts: []string{"cardi/o", "my/o", "-pathy"}, Meaning: "disease of the heart muscle"},
+	{Word: "pericarditis", Parts: []string{"peri-", "cardi/o", "-itis"}, Meaning: "inflammation around the heart"},
+	{Word: "endocarditis", Parts: []string{"endo-", "cardi/o", "-itis"}, Meaning: "inflammation of the lining within the heart"},
+	{Word: "angioplasty", Parts: []string{"angi/o", "-plasty"}, Meaning: "surgical repair of a vessel"},
+	{Word: "hematology", Parts: []string{"hemat/o", "-logy"}, Meaning: "study of the blood"},
+	{Word: "gastritis", Parts: []string{"gastr/o", "-itis"}, Meaning: "inflammation of the stomach"},
+	{Word: "gastrectomy", Parts: []string{"gastr/o", "-ectomy"}, Meaning: "surgical removal of the stomach"},
+	{Word: "gastroscopy", Parts: []string{"gastr/o", "-scopy"}, Meaning: "visual examination of the stomach"},
+	{Word: "gastroenteritis", Parts: []string{"gastr/o", "enter/o", "-itis"}, Meaning: "inflammation of the stomach and small intestine"},
+	{Word: "hepatitis", Parts: []string{"hepat/o", "-itis"}, Meaning: "inflammation of the liver"},
+	{Word: "hepatomegaly", Parts: []string{"hepat/o", "-megaly"}, Meaning: "enlargement of the liver"},
+	{Word: "hepatoma", Parts: []string{"hepat/o", "-oma"}, Meaning: "tumor of the liver"},
+	{Word: "colitis", Parts: []string{"col/o", "-itis"}, Meaning: "inflammation of the colon"},
+	{Word: "colectomy", Parts: []string{"col/o", "-ectomy"}, Meaning: "surgical removal of the colon"},
+	{Word: "nephritis", Parts: []string{"nephr/o", "-itis"}, Meaning: "inflammation of the kidney"},
+	{Word: "nephrectomy", Parts: []string{"nephr/o", "-ectomy"}, Meaning: "surgical removal of a kidney"},
+	{Word: "nephrology", Parts: []string{"nephr/o", "-logy"}, Meaning: "study of the kidneys"},
+	{Word: "nephrosis", Parts: []string{"nephr/o", "-osis"}, Meaning: "abnormal condition of the kidney"},
+	{Word: "cystitis", Parts: []string{"cyst/o", "-itis"}, Meaning: "inflammation of the bladder"},
+	{Word: "cystoscopy", Parts: []string{"cyst/o", "-scopy"}, Meaning: "visual examination of the bladder"},
+	{Word: "neuritis", Parts: []string{"neur/o", "-itis"}, Meaning: "inflammation of a nerve"},
+	{Word: "neuralgia", Parts: []string{"neur/o", "-algia"}, Meaning: "nerve pain"},
+	{Word: "neuropathy", Parts: []string{"neur/o", "-pathy"}, Meaning: "disease of the nerves"},
+	{Word: "polyneuritis", Parts: []string{"poly-", "neur/o", "-itis"}, Meaning: "inflammation of many nerves"},
+	{Word: "encephalitis", Parts: []string{"encephal/o", "-itis"}, Meaning: "inflammation of the brain"},
+	{Word: "osteoma", Parts: []string{"oste/o", "-oma"}, Meaning: "tumor of bone"},
+	{Word: "osteoarthritis", Parts: []string{"oste/o", "arthr/o", "-itis"}, Meaning: "inflammation of bone and joint"},
+	{Word: "arthritis", Parts: []string{"arthr/o", "-itis"}, Meaning: "inflammation of a joint"},
+	{Word: "arthralgia", Parts: []string{"arthr/o", "-algia"}, Meaning: "joint pain"},
+	{Word: "arthroscopy", Parts: []string{"arthr/o", "-scopy"}, Meaning: "visual examination of a joint"},
+	{Word: "arthroplasty", Parts: []string{"arthr/o", "-plasty"}, Meaning: "surgical repair of a joint"},
+	{Word: "myalgia", Parts: []string{"my/o", "-algia"}, Meaning: "muscle pain"},
+	{Word: "rhinitis", Parts: []string{"rhin/o", "-itis"}, Meaning: "inflammation of the nose"},
+	{Word: "rhinoplasty", Parts: []string{"rhin/o", "-plasty"}, Meaning: "surgical repair of the nose"},
+	{Word: "otitis", Parts: []string{"ot/o", "-itis"}, Meaning: "inflammation of the ear"},
+	{Word: "otalgia", Parts: []string{"ot/o", "-algia"}, Meaning: "ear pain"},
+	{Word: "ophthalmology", Parts: []string{"ophthalm/o", "-logy"}, Meaning: "study of the eye"},
+	{Word: "pneumonectomy", Parts: []string{"pneumon/o", "-ectomy"}, Meaning: "surgical removal of a lung"},
+}
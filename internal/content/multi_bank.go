@@ -0,0 +1,34 @@
+package content
+
+import "fmt"
+
+// MultiBank serves several banks as one. Question IDs must not overlap
+// between them; the first bank holding an ID wins.
+type MultiBank struct {
+	banks []QuestionBank
+}
+
+func NewMultiBank(banks ...QuestionBank) *MultiBank {
+	return &MultiBank{banks: banks}
+}
+
+func (mb *MultiBank) GetAll() ([]Question, error) {
+	var questions []Question
+	for _, bank := range mb.banks {
+		qs, err := bank.GetAll()
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, qs...)
+	}
+	return questions, nil
+}
+
+func (mb *MultiBank) GetQuestionByID(id int) (*Question, error) {
+	for _, bank := range mb.banks {
+		if q, err := bank.GetQuestionByID(id); err == nil {
+			return q, nil
+		}
+	}
+	return nil, fmt.Errorf("question ID %d not found", id)
+}
@@ -7,19 +7,41 @@ type QuestionBank interface {
 
 type Question struct{
 	ID int
+	Type QuestionType // Empty means multiple choice
 	Text string
-	Answer string
+	Answer string // The correct answer, as shown to the learner
+	Answers []string // Correct options (multi-select), order (ordering) or blank fills (term building)
+	Synonyms map[string][]string // Alternate accepted spellings for typed answers, keyed by the answer they stand for
+	MaxEdits int // Typed answers within this many edits of an accepted answer get partial credit; 0 turns it off
+	Rubric []RubricCriterion // What an explanation must cover (explanation questions)
 	Metadata QuestionMetadata
 	Options []string
 	Feedback string // Static feedback for BKT mode
+	OptionFeedback map[string]string // Feedback for each wrong option, keyed by option text
+	Hints []string // Optional hints, revealed one tier at a time from a nudge to nearly the answer
 }
 
 type QuestionMetadata struct {
 	Difficulty float64
 	Tags []string
+	IRT IRTParams // 3PL item calibration, used by the IRT selector
+}
+
+// IRTParams are three-parameter logistic item parameters:
+// A is discrimination, B is difficulty on the ability (theta) scale and C is the guessing floor.
+type IRTParams struct {
+	A float64
+	B float64
+	C float64
 }
 
 type AnswerRecord struct {
 	QuestionID int
 	Correct bool
+	Score float64 // Credit from 0 to 1; between the two for near misses and partly right multi-part answers
+	UserAnswer string // The option the learner chose
+	HintsUsed int // Hint tiers revealed before answering
+	HintHelp float64 // How much those hints gave away, see HintHelp
+	LatencyMS int64 // How long the answer took, client-measured when available; 0 if unknown
+	Confidence int // How sure the learner said they were, see MaxConfidence; 0 if not rated
 }
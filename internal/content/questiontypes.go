@@ -0,0 +1,360 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-adapt/internal/grading"
+	"strings"
+)
+
+// QuestionType says how a question is answered and graded
+type QuestionType string
+
+const (
+	MultipleChoice QuestionType = "multiple_choice" // Pick one of Options; the default
+	FreeText       QuestionType = "free_text"       // Type the Answer, e.g. a spelling
+	MultiSelect    QuestionType = "multi_select"    // Pick every option in Answers
+	Ordering       QuestionType = "ordering"        // Arrange Options into the order of Answers
+	TermBuilding   QuestionType = "term_building"   // Fill each ___ blank in Text with the matching entry of Answers
+	Explanation    QuestionType = "explanation"     // Explain in writing; graded against Rubric by a RubricGrader
+)
+
+// BlankMarker marks a blank to fill in a term-building question's text
+const BlankMarker = "___"
+
+// AnswerSchema tells clients what shape of answer a question expects
+type AnswerSchema struct {
+	Type QuestionType `json:"type"`
+	// Kind is "string" for a single value or "array" for a list of strings
+	Kind string `json:"kind"`
+	// Count is the exact number of entries an array answer must have, or 0 if any number is allowed
+	Count       int    `json:"count,omitempty"`
+	Description string `json:"description"`
+}
+
+// Grade is the result of scoring one answer
+type Grade struct {
+	Correct bool
+	Score   float64           // Fraction of the answer that was right, from 0 to 1
+	Match   grading.MatchType // For multi-part answers, the weakest part's match
+
+	// Rubric-graded answers only
+	Criteria []CriterionResult
+	Feedback string
+}
+
+// ErrInvalidAnswer means an answer has the wrong shape for its question type
+var ErrInvalidAnswer = errors.New("invalid answer")
+
+// questionType holds the schema, validation and scoring for one type.
+// Types without a grade function need a RubricGrader.
+type questionType struct {
+	kind        string
+	description string
+	fromOptions bool // Answers are option texts; options may be shown in any order, so never positions
+	count       func(q *Question) int
+	validate    func(q *Question) error
+	grade       func(q *Question, answer []string) Grade
+}
+
+var questionTypes = map[QuestionType]questionType{
+	MultipleChoice: {
+		kind:        "string",
+		fromOptions: true,
+		description: "one of the question's options",
+		validate: func(q *Question) error {
+			if !contains(q.Options, q.Answer) {
+				return fmt.Errorf("answer %q is not one of the options", q.Answer)
+			}
+			return nil
+		},
+		grade: func(q *Question, answer []string) Grade {
+			return gradeAll(answer[0] == q.Answer)
+		},
+	},
+	FreeText: {
+		kind:        "string",
+		description: "the answer, typed",
+		validate: func(q *Question) error {
+			if strings.TrimSpace(q.Answer) == "" {
+				return errors.New("no answer")
+			}
+			return validateSynonyms(q, []string{q.Answer})
+		},
+		grade: func(q *Question, answer []string) Grade {
+			return gradeTyped(q, answer, []string{q.Answer})
+		},
+	},
+	MultiSelect: {
+		kind:        "array",
+		description: "every option that applies, in any order",
+		fromOptions: true,
+		validate: func(q *Question) error {
+			if len(q.Answers) == 0 {
+				return errors.New("no correct options")
+			}
+			for _, a := range q.Answers {
+				if !contains(q.Options, a) {
+					return fmt.Errorf("correct option %q is not one of the options", a)
+				}
+			}
+			return nil
+		},
+		grade: gradeSelection,
+	},
+	Ordering: {
+		kind:        "array",
+		description: "all of the options, in order",
+		fromOptions: true,
+		count:       func(q *Question) int { return len(q.Answers) },
+		validate: func(q *Question) error {
+			if len(q.Answers) == 0 {
+				return errors.New("no correct order")
+			}
+			if !samePermutation(q.Options, q.Answers) {
+				return errors.New("options are not a rearrangement of the correct order")
+			}
+			return nil
+		},
+		grade: gradePositions,
+	},
+	TermBuilding: {
+		kind:        "array",
+		description: "one word part per blank, in order",
+		count:       func(q *Question) int { return len(q.Answers) },
+		validate: func(q *Question) error {
+			if len(q.Answers) == 0 {
+				return errors.New("no blanks to fill")
+			}
+			if blanks := strings.Count(q.Text, BlankMarker); blanks != len(q.Answers) {
+				return fmt.Errorf("text has %d blanks but %d answers", blanks, len(q.Answers))
+			}
+			return validateSynonyms(q, q.Answers)
+		},
+		grade: func(q *Question, answer []string) Grade {
+			return gradeTyped(q, answer, q.Answers)
+		},
+	},
+	Explanation: {
+		kind:        "string",
+		description: "a short written explanation",
+		validate:    validateRubric,
+	},
+}
+
+// QuestionType returns the question's type, defaulting to multiple choice
+func (q *Question) QuestionType() QuestionType {
+	if q.Type == "" {
+		return MultipleChoice
+	}
+	return q.Type
+}
+
+// AnswerSchema describes the answer this question expects
+func (q *Question) AnswerSchema() AnswerSchema {
+	qt := questionTypes[q.QuestionType()]
+	schema := AnswerSchema{Type: q.QuestionType(), Kind: qt.kind, Description: qt.description}
+	if qt.count != nil {
+		schema.Count = qt.count(q)
+	}
+	return schema
+}
+
+// Grade scores an answer. Answers of the wrong shape, or naming an option the
+// question doesn't have, return ErrInvalidAnswer; wrong answers of the right
+// shape are graded incorrect. Explanation questions return ErrNoRubricGrader;
+// use GradeWith.
+func (q *Question) Grade(answer []string) (Grade, error) {
+	return q.GradeWith(context.Background(), answer, nil)
+}
+
+// GradeWith scores an answer like Grade, judging explanations with rubric
+// within ctx
+func (q *Question) GradeWith(ctx context.Context, answer []string, rubric RubricGrader) (Grade, error) {
+	qt, ok := questionTypes[q.QuestionType()]
+	if !ok {
+		return Grade{}, fmt.Errorf("question %d: unknown question type %q", q.ID, q.Type)
+	}
+	schema := q.AnswerSchema()
+	if schema.Kind == "string" && len(answer) != 1 {
+		return Grade{}, fmt.Errorf("%w: question %d expects a single answer", ErrInvalidAnswer, q.ID)
+	}
+	if schema.Count > 0 && len(answer) != schema.Count {
+		return Grade{}, fmt.Errorf("%w: question %d expects %d answers, got %d", ErrInvalidAnswer, q.ID, schema.Count, len(answer))
+	}
+	if qt.fromOptions {
+		for _, a := range answer {
+			if !contains(q.Options, a) {
+				return Grade{}, fmt.Errorf("%w: %q is not one of question %d's options; send the option text", ErrInvalidAnswer, a, q.ID)
+			}
+		}
+	}
+	if qt.grade != nil {
+		return qt.grade(q, answer), nil
+	}
+
+	if rubric == nil {
+		return Grade{}, fmt.Errorf("%w: question %d is graded against a rubric", ErrNoRubricGrader, q.ID)
+	}
+	if strings.TrimSpace(answer[0]) == "" {
+		return Grade{Match: grading.NoMatch}, nil // Nothing to send to the grader
+	}
+	results, feedback, err := rubric.GradeRubric(ctx, q, answer[0])
+	if err != nil {
+		return Grade{}, fmt.Errorf("question %d: rubric grading failed: %w", q.ID, err)
+	}
+	criteria, score := ScoreRubric(q.Rubric, results)
+	return Grade{
+		Correct:  score >= ExplanationPassScore,
+		Score:    score,
+		Match:    grading.Rubric,
+		Criteria: criteria,
+		Feedback: feedback,
+	}, nil
+}
+
+// ValidateQuestions checks that every question is well formed for its type
+// and that IDs are unique
+func ValidateQuestions(questions []Question) error {
+	seen := make(map[int]bool, len(questions))
+	for i := range questions {
+		q := &questions[i]
+		if seen[q.ID] {
+			return fmt.Errorf("duplicate question ID %d", q.ID)
+		}
+		seen[q.ID] = true
+
+		qt, ok := questionTypes[q.QuestionType()]
+		if !ok {
+			return fmt.Errorf("question %d: unknown question type %q", q.ID, q.Type)
+		}
+		if err := qt.validate(q); err != nil {
+			return fmt.Errorf("question %d (%s): %w", q.ID, q.QuestionType(), err)
+		}
+		if err := validateHints(q.Hints); err != nil {
+			return fmt.Errorf("question %d: %w", q.ID, err)
+		}
+	}
+	return nil
+}
+
+// ParseAnswer decodes a submitted answer, which is either a JSON string or an array of strings
+func ParseAnswer(raw json.RawMessage) ([]string, error) {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []string{single}, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("%w: expected a string or an array of strings", ErrInvalidAnswer)
+	}
+	return list, nil
+}
+
+// FormatAnswer renders an answer as one string for history and logs
+func FormatAnswer(answer []string) string {
+	return strings.Join(answer, "; ")
+}
+
+func gradeAll(correct bool) Grade {
+	if correct {
+		return Grade{Correct: true, Score: 1, Match: grading.Exact}
+	}
+	return Grade{Match: grading.NoMatch}
+}
+
+// gradeTyped matches each typed part against the expected part leniently (see
+// grading.Match) and averages the parts' scores
+func gradeTyped(q *Question, answer, expected []string) Grade {
+	grade := Grade{Correct: true, Match: grading.Exact}
+	for i, want := range expected {
+		result := grading.Match(answer[i], want, grading.Options{Synonyms: q.Synonyms[want], MaxEdits: q.MaxEdits})
+		grade.Correct = grade.Correct && result.Correct
+		grade.Score += result.Score / float64(len(expected))
+		grade.Match = grading.Worse(grade.Match, result.Match)
+	}
+	return grade
+}
+
+// validateSynonyms checks that synonyms are only configured for answers the question has
+func validateSynonyms(q *Question, answers []string) error {
+	if q.MaxEdits < 0 {
+		return errors.New("negative MaxEdits")
+	}
+	for key := range q.Synonyms {
+		if !contains(answers, key) {
+			return fmt.Errorf("synonyms for %q, which is not an answer", key)
+		}
+	}
+	return nil
+}
+
+// gradeSelection credits each correct option picked and takes one back for
+// each wrong pick, so selecting everything doesn't score
+func gradeSelection(q *Question, answer []string) Grade {
+	picked := make(map[string]bool, len(answer))
+	for _, a := range answer {
+		picked[a] = true
+	}
+	hits := 0
+	for _, a := range q.Answers {
+		if picked[a] {
+			hits++
+		}
+	}
+	wrong := len(picked) - hits
+
+	score := float64(hits-wrong) / float64(len(q.Answers))
+	if score < 0 {
+		score = 0
+	}
+	return withMatch(Grade{Correct: hits == len(q.Answers) && wrong == 0, Score: score})
+}
+
+// gradePositions credits each entry that matches the correct answer at the same position
+func gradePositions(q *Question, answer []string) Grade {
+	hits := 0
+	for i, a := range q.Answers {
+		if answer[i] == a {
+			hits++
+		}
+	}
+	return withMatch(Grade{Correct: hits == len(q.Answers), Score: float64(hits) / float64(len(q.Answers))})
+}
+
+// withMatch sets the match type for answers chosen from options, which are either exactly right or not
+func withMatch(g Grade) Grade {
+	g.Match = grading.NoMatch
+	if g.Correct {
+		g.Match = grading.Exact
+	}
+	return g
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func samePermutation(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		counts[v]--
+		if counts[v] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"go-adapt/internal/grading"
+	"math"
+	"testing"
+)
+
+func TestGradeWithEachType(t *testing.T) {
+	choice := &Question{ID: 1, Options: []string{"liver", "kidney", "heart"}, Answer: "liver"}
+	typed := &Question{
+		ID:       2,
+		Type:     FreeText,
+		Answer:   "hepatitis",
+		Synonyms: map[string][]string{"hepatitis": {"liver inflammation"}},
+		MaxEdits: 2,
+	}
+	selection := &Question{ID: 3, Type: MultiSelect, Options: []string{"cardi/o", "hepat/o", "-itis", "nephr/o"}, Answers: []string{"cardi/o", "hepat/o", "nephr/o"}}
+	ordering := &Question{ID: 4, Type: Ordering, Options: []string{"-itis", "gastr/o", "enter/o"}, Answers: []string{"gastr/o", "enter/o", "-itis"}}
+	building := &Question{ID: 5, Type: TermBuilding, Text: "___ + ___ = inflammation of the liver", Answers: []string{"hepat/o", "-itis"}, MaxEdits: 1}
+
+	tests := []struct {
+		name    string
+		q       *Question
+		answer  []string
+		correct bool
+		score   float64
+		match   grading.MatchType
+	}{
+		{"multiple choice right", choice, []string{"liver"}, true, 1, grading.Exact},
+		{"multiple choice wrong", choice, []string{"heart"}, false, 0, grading.NoMatch},
+
+		{"free text exact", typed, []string{"hepatitis"}, true, 1, grading.Exact},
+		{"free text normalized", typed, []string{" Hepatitis "}, true, 1, grading.Normalized},
+		{"free text synonym", typed, []string{"Liver inflammation"}, true, 1, grading.Synonym},
+		{"free text near miss", typed, []string{"hepatitus"}, false, 1 - 1.0/9, grading.NearMiss},
+		{"free text wrong", typed, []string{"nephritis"}, false, 0, grading.NoMatch},
+
+		{"multi-select all", selection, []string{"nephr/o", "cardi/o", "hepat/o"}, true, 1, grading.Exact},
+		{"multi-select partial", selection, []string{"cardi/o", "hepat/o"}, false, 2.0 / 3, grading.NoMatch},
+		{"multi-select wrong pick takes credit back", selection, []string{"cardi/o", "hepat/o", "-itis"}, false, 1.0 / 3, grading.NoMatch},
+		{"multi-select everything", selection, []string{"cardi/o", "hepat/o", "-itis", "nephr/o"}, false, 2.0 / 3, grading.NoMatch},
+		{"multi-select only wrong", selection, []string{"-itis"}, false, 0, grading.NoMatch},
+
+		{"ordering right", ordering, []string{"gastr/o", "enter/o", "-itis"}, true, 1, grading.Exact},
+		{"ordering one in place", ordering, []string{"gastr/o", "-itis", "enter/o"}, false, 1.0 / 3, grading.NoMatch},
+		{"ordering none in place", ordering, []string{"-itis", "gastr/o", "enter/o"}, false, 0, grading.NoMatch},
+
+		{"term building right", building, []string{"hepat/o", "-itis"}, true, 1, grading.Exact},
+		{"term building normalized", building, []string{"hepato", "itis"}, true, 1, grading.Normalized},
+		{"term building one near miss", building, []string{"hepat/o", "itus"}, false, 0.5 + 0.5*(1-1.0/4), grading.NearMiss},
+		{"term building one wrong", building, []string{"nephr/o", "-itis"}, false, 0.5, grading.NoMatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grade, err := tt.q.GradeWith(context.Background(), tt.answer, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if grade.Correct != tt.correct || math.Abs(grade.Score-tt.score) > 1e-9 || grade.Match != tt.match {
+				t.Errorf("grade = %v %v %s, want %v %v %s", grade.Correct, grade.Score, grade.Match, tt.correct, tt.score, tt.match)
+			}
+		})
+	}
+}
+
+func TestGradeWithRejectsMisshapenAnswers(t *testing.T) {
+	choice := &Question{ID: 1, Options: []string{"liver", "kidney"}, Answer: "liver"}
+	selection := &Question{ID: 2, Type: MultiSelect, Options: []string{"a", "b", "c"}, Answers: []string{"a", "b"}}
+	ordering := &Question{ID: 3, Type: Ordering, Options: []string{"b", "a"}, Answers: []string{"a", "b"}}
+	building := &Question{ID: 4, Type: TermBuilding, Text: "___ ___", Answers: []string{"a", "b"}}
+
+	tests := []struct {
+		name   string
+		q      *Question
+		answer []string
+	}{
+		{"two answers to a single-answer question", choice, []string{"liver", "kidney"}},
+		{"no answer", choice, nil},
+		{"option position instead of text", choice, []string{"0"}},
+		{"unknown option in a selection", selection, []string{"a", "d"}},
+		{"too few entries to order", ordering, []string{"a"}},
+		{"too many blanks filled", building, []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.q.GradeWith(context.Background(), tt.answer, nil); !errors.Is(err, ErrInvalidAnswer) {
+				t.Errorf("err = %v, want ErrInvalidAnswer", err)
+			}
+		})
+	}
+
+	unknown := &Question{ID: 5, Type: "essay"}
+	if _, err := unknown.GradeWith(context.Background(), []string{"x"}, nil); err == nil {
+		t.Error("graded a question of unknown type")
+	}
+}
@@ -0,0 +1,78 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Rubric grading for explanation questions. The grader (usually the LLM) only
+// judges each criterion; the score is computed here so every grader scores alike.
+
+// ExplanationPassScore is the rubric score at which an explanation counts as correct
+const ExplanationPassScore = 0.8
+
+// RubricCriterion is one thing a good explanation covers
+type RubricCriterion struct {
+	ID          string
+	Description string
+	Points      float64
+}
+
+// CriterionResult is the credit an explanation earned on one criterion
+type CriterionResult struct {
+	ID      string  `json:"id"`
+	Points  float64 `json:"points"`
+	Comment string  `json:"comment,omitempty"`
+}
+
+// RubricGrader judges a free-text explanation against each of a question's
+// rubric criteria, giving up when ctx is done
+type RubricGrader interface {
+	GradeRubric(ctx context.Context, q *Question, explanation string) (criteria []CriterionResult, feedback string, err error)
+}
+
+// ErrNoRubricGrader means an explanation question was graded without a rubric grader
+var ErrNoRubricGrader = errors.New("no rubric grader available")
+
+// ScoreRubric lines results up with the rubric, in rubric order. Points are
+// clamped to each criterion's range, criteria without a result earn nothing and
+// results for unknown criteria are dropped. The score is the fraction of points earned.
+func ScoreRubric(rubric []RubricCriterion, results []CriterionResult) ([]CriterionResult, float64) {
+	byID := make(map[string]CriterionResult, len(results))
+	for _, r := range results {
+		byID[r.ID] = r
+	}
+
+	scored := make([]CriterionResult, 0, len(rubric))
+	var earned, total float64
+	for _, criterion := range rubric {
+		r := byID[criterion.ID]
+		r.ID = criterion.ID
+		r.Points = max(0, min(criterion.Points, r.Points))
+		scored = append(scored, r)
+		earned += r.Points
+		total += criterion.Points
+	}
+	if total == 0 {
+		return scored, 0
+	}
+	return scored, earned / total
+}
+
+func validateRubric(q *Question) error {
+	if len(q.Rubric) == 0 {
+		return errors.New("no rubric")
+	}
+	seen := make(map[string]bool, len(q.Rubric))
+	for _, criterion := range q.Rubric {
+		if criterion.ID == "" || seen[criterion.ID] {
+			return fmt.Errorf("rubric criterion ID %q is empty or repeated", criterion.ID)
+		}
+		seen[criterion.ID] = true
+		if criterion.Points <= 0 {
+			return fmt.Errorf("rubric criterion %q has no points", criterion.ID)
+		}
+	}
+	return nil
+}
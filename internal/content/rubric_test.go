@@ -0,0 +1,127 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"go-adapt/internal/grading"
+	"math"
+	"testing"
+)
+
+// fakeGrader returns canned criterion results and counts its calls
+type fakeGrader struct {
+	results  []CriterionResult
+	feedback string
+	err      error
+	calls    int
+	ctx      context.Context
+}
+
+func (g *fakeGrader) GradeRubric(ctx context.Context, q *Question, explanation string) ([]CriterionResult, string, error) {
+	g.calls++
+	g.ctx = ctx
+	return g.results, g.feedback, g.err
+}
+
+var testRubric = []RubricCriterion{
+	{ID: "root", Points: 1},
+	{ID: "suffix", Points: 1},
+	{ID: "combination", Points: 2},
+}
+
+func TestScoreRubric(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []CriterionResult
+		points  []float64 // Per criterion, in rubric order
+		score   float64
+	}{
+		{"full credit", []CriterionResult{{ID: "root", Points: 1}, {ID: "suffix", Points: 1}, {ID: "combination", Points: 2}}, []float64{1, 1, 2}, 1},
+		{"clamped above", []CriterionResult{{ID: "root", Points: 5}, {ID: "suffix", Points: 1}, {ID: "combination", Points: 2}}, []float64{1, 1, 2}, 1},
+		{"clamped below", []CriterionResult{{ID: "root", Points: -3}, {ID: "suffix", Points: 1}, {ID: "combination", Points: 2}}, []float64{0, 1, 2}, 0.75},
+		{"missing criteria", []CriterionResult{{ID: "combination", Points: 1}}, []float64{0, 0, 1}, 0.25},
+		{"unknown IDs dropped", []CriterionResult{{ID: "root", Points: 1}, {ID: "spelling", Points: 4}}, []float64{1, 0, 0}, 0.25},
+		{"no results", nil, []float64{0, 0, 0}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scored, score := ScoreRubric(testRubric, tt.results)
+			if math.Abs(score-tt.score) > 1e-9 {
+				t.Errorf("score = %v, want %v", score, tt.score)
+			}
+			if len(scored) != len(testRubric) {
+				t.Fatalf("got %d results, want one per criterion", len(scored))
+			}
+			for i, r := range scored {
+				if r.ID != testRubric[i].ID || r.Points != tt.points[i] {
+					t.Errorf("result %d = %s %v, want %s %v", i, r.ID, r.Points, testRubric[i].ID, tt.points[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScoreRubricWithoutPoints(t *testing.T) {
+	if _, score := ScoreRubric(nil, []CriterionResult{{ID: "root", Points: 1}}); score != 0 {
+		t.Errorf("empty rubric scored %v", score)
+	}
+}
+
+func TestGradeWithExplanation(t *testing.T) {
+	q := &Question{ID: 1, Type: Explanation, Text: "Explain.", Rubric: testRubric}
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+
+	tests := []struct {
+		name    string
+		answer  string
+		grader  fakeGrader
+		calls   int
+		correct bool
+		score   float64
+		match   grading.MatchType
+	}{
+		{"passes", "hepat/o is liver, -megaly is enlargement", fakeGrader{results: []CriterionResult{{ID: "root", Points: 1}, {ID: "suffix", Points: 1}, {ID: "combination", Points: 2}}, feedback: "Complete."}, 1, true, 1, grading.Rubric},
+		{"below pass score", "hepat/o is liver", fakeGrader{results: []CriterionResult{{ID: "root", Points: 1}, {ID: "combination", Points: 2}}}, 1, false, 0.75, grading.Rubric},
+		{"empty answer", "", fakeGrader{}, 0, false, 0, grading.NoMatch},
+		{"blank answer", "  \n\t", fakeGrader{}, 0, false, 0, grading.NoMatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grade, err := q.GradeWith(ctx, []string{tt.answer}, &tt.grader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.grader.calls != tt.calls {
+				t.Errorf("grader called %d times, want %d", tt.grader.calls, tt.calls)
+			}
+			if tt.calls > 0 && tt.grader.ctx != ctx {
+				t.Error("grader didn't get the caller's context")
+			}
+			if grade.Correct != tt.correct || math.Abs(grade.Score-tt.score) > 1e-9 || grade.Match != tt.match {
+				t.Errorf("grade = %v %v %s, want %v %v %s", grade.Correct, grade.Score, grade.Match, tt.correct, tt.score, tt.match)
+			}
+			if grade.Feedback != tt.grader.feedback {
+				t.Errorf("feedback = %q, want %q", grade.Feedback, tt.grader.feedback)
+			}
+		})
+	}
+}
+
+func TestGradeWithExplanationErrors(t *testing.T) {
+	q := &Question{ID: 1, Type: Explanation, Text: "Explain.", Rubric: testRubric}
+
+	if _, err := q.Grade([]string{"an answer"}); !errors.Is(err, ErrNoRubricGrader) {
+		t.Errorf("Grade without a grader: %v, want ErrNoRubricGrader", err)
+	}
+
+	failure := errors.New("grader unavailable")
+	grader := &fakeGrader{err: failure}
+	if _, err := q.GradeWith(context.Background(), []string{"an answer"}, grader); !errors.Is(err, failure) {
+		t.Errorf("grader failure: %v, want it wrapped", err)
+	}
+
+	if _, err := q.GradeWith(context.Background(), []string{"one", "two"}, grader); !errors.Is(err, ErrInvalidAnswer) {
+		t.Errorf("two answers: %v, want ErrInvalidAnswer", err)
+	}
+}
@@ -0,0 +1,26 @@
+package content
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// WithShuffledOptions returns a copy of the question with its options in an
+// order fixed by seed and the question ID, so serving the same question again
+// with the same seed shows the same order. Answers are graded by option text,
+// so the order never affects grading.
+func (q Question) WithShuffledOptions(seed int64) Question {
+	if len(q.Options) < 2 {
+		return q
+	}
+	rng := rand.New(rand.NewSource(seed*1_000_003 + int64(q.ID)))
+	options := slices.Clone(q.Options)
+	rng.Shuffle(len(options), func(i, j int) { options[i], options[j] = options[j], options[i] })
+
+	// An ordering question shown already in order would give the answer away
+	if q.QuestionType() == Ordering && slices.Equal(options, q.Answers) {
+		options = append(options[1:], options[0])
+	}
+	q.Options = options
+	return q
+}
@@ -0,0 +1,53 @@
+package content
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWithShuffledOptionsIsStablePerSeed(t *testing.T) {
+	q, err := NewStaticBank().GetQuestionByID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := q.WithShuffledOptions(42).Options
+	for range 5 {
+		if again := q.WithShuffledOptions(42).Options; !slices.Equal(first, again) {
+			t.Fatalf("seed 42 gave %v, then %v", first, again)
+		}
+	}
+	if !slices.Equal(slices.Sorted(slices.Values(first)), slices.Sorted(slices.Values(q.Options))) {
+		t.Errorf("shuffled options %v are not a permutation of %v", first, q.Options)
+	}
+
+	differs := false
+	for seed := int64(0); seed < 20 && !differs; seed++ {
+		differs = !slices.Equal(first, q.WithShuffledOptions(seed).Options)
+	}
+	if !differs {
+		t.Error("every seed gave the same order")
+	}
+}
+
+func TestWithShuffledOptionsNeverServesOrderingSolved(t *testing.T) {
+	q := Question{
+		ID:      7,
+		Type:    Ordering,
+		Text:    "Order the word parts",
+		Options: []string{"a", "b"}, // Two options: half of all shuffles come out solved
+		Answers: []string{"a", "b"},
+	}
+	for seed := int64(0); seed < 200; seed++ {
+		if served := q.WithShuffledOptions(seed).Options; slices.Equal(served, q.Answers) {
+			t.Fatalf("seed %d served the ordering question already in order", seed)
+		}
+	}
+}
+
+func TestWithShuffledOptionsLeavesOriginal(t *testing.T) {
+	q := Question{ID: 3, Options: []string{"a", "b", "c", "d"}, Answer: "a"}
+	q.WithShuffledOptions(5)
+	if !slices.Equal(q.Options, []string{"a", "b", "c", "d"}) {
+		t.Errorf("shuffling changed the bank's options to %v", q.Options)
+	}
+}
@@ -0,0 +1,167 @@
+package content
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Skill is a unit of mastery evidenced by questions carrying any of its Tags.
+// A skill is unlocked once every skill in Prerequisites is mastered.
+type Skill struct {
+	Name          string
+	Tags          []string
+	Prerequisites []string
+}
+
+// SkillGraph is a validated, acyclic prerequisite graph over skills
+type SkillGraph struct {
+	skills map[string]Skill
+	order  []string // Topological order, prerequisites first
+	byTag  map[string][]string
+}
+
+// NewSkillGraph validates the skills and builds the graph. It fails on duplicate
+// skills, prerequisites that aren't defined, and prerequisite cycles.
+func NewSkillGraph(skills []Skill) (*SkillGraph, error) {
+	sg := &SkillGraph{
+		skills: make(map[string]Skill, len(skills)),
+		byTag:  make(map[string][]string),
+	}
+	for _, skill := range skills {
+		if _, dup := sg.skills[skill.Name]; dup {
+			return nil, fmt.Errorf("duplicate skill %q", skill.Name)
+		}
+		sg.skills[skill.Name] = skill
+		for _, tag := range skill.Tags {
+			sg.byTag[tag] = append(sg.byTag[tag], skill.Name)
+		}
+	}
+	for _, skill := range skills {
+		for _, prereq := range skill.Prerequisites {
+			if _, ok := sg.skills[prereq]; !ok {
+				return nil, fmt.Errorf("skill %q has unknown prerequisite %q", skill.Name, prereq)
+			}
+		}
+	}
+
+	// Depth-first topological sort; meeting a skill that is still on the stack means a cycle
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(skills))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("prerequisite cycle: %v", append(path, name))
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		for _, prereq := range sg.skills[name].Prerequisites {
+			if err := visit(prereq, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		sg.order = append(sg.order, name)
+		return nil
+	}
+	for _, skill := range skills {
+		if err := visit(skill.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sg, nil
+}
+
+// Skills returns every skill name, prerequisites before the skills that need them
+func (sg *SkillGraph) Skills() []string {
+	return sg.order
+}
+
+func (sg *SkillGraph) Prerequisites(skill string) []string {
+	return sg.skills[skill].Prerequisites
+}
+
+// SkillsFor returns the skills a question provides evidence for, sorted by name
+func (sg *SkillGraph) SkillsFor(q *Question) []string {
+	seen := make(map[string]bool)
+	var skills []string
+	for _, tag := range q.Metadata.Tags {
+		for _, skill := range sg.byTag[tag] {
+			if !seen[skill] {
+				seen[skill] = true
+				skills = append(skills, skill)
+			}
+		}
+	}
+	sort.Strings(skills)
+	return skills
+}
+
+// Tags returns every tag that maps to a skill, sorted
+func (sg *SkillGraph) Tags() []string {
+	tags := make([]string, 0, len(sg.byTag))
+	for tag := range sg.byTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Unlocked reports whether every prerequisite of skill is in mastered
+func (sg *SkillGraph) Unlocked(skill string, mastered map[string]bool) bool {
+	for _, prereq := range sg.skills[skill].Prerequisites {
+		if !mastered[prereq] {
+			return false
+		}
+	}
+	return true
+}
+
+// NewMedicalTerminologySkillGraph returns the prerequisite graph for the static bank:
+// word parts are identified before terms are built, and terms are built before
+// they are reasoned about by analogy or decomposed.
+func NewMedicalTerminologySkillGraph() (*SkillGraph, error) {
+	return NewSkillGraph(medicalTerminologySkills)
+}
+
+var medicalTerminologySkills = []Skill{
+	{
+		Name: "roots",
+		Tags: []string{"root identification", "basic roots", "organ roots", "hemat/o root", "specialty identification"},
+	},
+	{
+		Name: "suffixes",
+		Tags: []string{"suffix identification", "basicsuffixes", "suffix distinction", "surgical suffix", "advanced suffix"},
+	},
+	{
+		Name: "prefixes",
+		Tags: []string{"prefix identification", "common prefixes", "prefix distinction", "prefix selection"},
+	},
+	{
+		Name:          "term construction",
+		Tags:          []string{"term construction", "suffix selection"},
+		Prerequisites: []string{"roots", "suffixes"},
+	},
+	{
+		Name:          "analogical reasoning",
+		Tags:          []string{"analogical reasoning", "suffix pattern"},
+		Prerequisites: []string{"term construction"},
+	},
+	{
+		Name: "term decomposition",
+		Tags: []string{"multi-part term", "meaning decomposition", "term decomposition", "prefix + root + suffix",
+			"multi-part construction", "multi-root term", "structural analysis"},
+		Prerequisites: []string{"term construction", "prefixes"},
+	},
+	{
+		Name: "complex terms",
+		Tags: []string{"complex multi-part term", "three components", "highly complex term",
+			"multi-root construction", "anatomical layers"},
+		Prerequisites: []string{"term decomposition", "analogical reasoning"},
+	},
+}
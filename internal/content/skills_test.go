@@ -0,0 +1,90 @@
+package content
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewSkillGraphRejectsBadGraphs(t *testing.T) {
+	tests := []struct {
+		name   string
+		skills []Skill
+		want   string // Part of the error
+	}{
+		{"self prerequisite", []Skill{{Name: "roots", Prerequisites: []string{"roots"}}}, "cycle"},
+		{"two-skill cycle", []Skill{
+			{Name: "roots", Prerequisites: []string{"terms"}},
+			{Name: "terms", Prerequisites: []string{"roots"}},
+		}, "cycle"},
+		{"cycle behind an acyclic start", []Skill{
+			{Name: "analogy", Prerequisites: []string{"terms"}},
+			{Name: "terms", Prerequisites: []string{"roots"}},
+			{Name: "roots", Prerequisites: []string{"suffixes"}},
+			{Name: "suffixes", Prerequisites: []string{"terms"}},
+		}, "cycle"},
+		{"unknown prerequisite", []Skill{{Name: "terms", Prerequisites: []string{"roots"}}}, "unknown prerequisite"},
+		{"duplicate skill", []Skill{{Name: "roots"}, {Name: "roots"}}, "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSkillGraph(tt.skills)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkillGraphOrdersPrerequisitesFirst(t *testing.T) {
+	skills := []Skill{
+		{Name: "analogy", Prerequisites: []string{"terms", "roots"}},
+		{Name: "terms", Prerequisites: []string{"roots", "suffixes"}},
+		{Name: "suffixes", Tags: []string{"suffix"}},
+		{Name: "roots", Tags: []string{"root", "combining form"}},
+	}
+	graph, err := NewSkillGraph(skills)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPrerequisitesFirst(t, graph)
+	if got := len(graph.Skills()); got != len(skills) {
+		t.Errorf("%d skills in order, want %d", got, len(skills))
+	}
+
+	mastered := map[string]bool{"roots": true}
+	if graph.Unlocked("terms", mastered) || !graph.Unlocked("suffixes", mastered) {
+		t.Error("terms unlocked without suffixes, or suffixes locked with no prerequisites")
+	}
+	mastered["suffixes"] = true
+	if !graph.Unlocked("terms", mastered) {
+		t.Error("terms locked with every prerequisite mastered")
+	}
+
+	q := &Question{Metadata: QuestionMetadata{Tags: []string{"suffix", "root", "combining form"}}}
+	if got := strings.Join(graph.SkillsFor(q), ","); got != "roots,suffixes" {
+		t.Errorf("SkillsFor = %s, want roots,suffixes", got)
+	}
+}
+
+func TestMedicalTerminologySkillGraph(t *testing.T) {
+	graph, err := NewMedicalTerminologySkillGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPrerequisitesFirst(t, graph)
+}
+
+func assertPrerequisitesFirst(t *testing.T, graph *SkillGraph) {
+	t.Helper()
+	position := make(map[string]int)
+	for i, skill := range graph.Skills() {
+		position[skill] = i
+	}
+	for _, skill := range graph.Skills() {
+		for _, prereq := range graph.Prerequisites(skill) {
+			if position[prereq] >= position[skill] {
+				t.Errorf("%s comes before its prerequisite %s", skill, prereq)
+			}
+		}
+	}
+}
@@ -33,9 +33,19 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"dermat/o", "-itis", "derma", "derm-itis"},
           Metadata: QuestionMetadata{
               Difficulty: 0.1,
+              IRT:        IRTParams{A: 0.8, B: -1.6, C: 0.2},
               Tags: []string{"root identification", "basic roots", "dermatology"},
           },
           Feedback: "The root 'dermat/o' means skin, while '-itis' means inflammation. Understanding roots is the foundation of medical terminology.",
+          OptionFeedback: map[string]string{
+              "-itis": "'-itis' is the suffix meaning inflammation, not skin. The root that means skin is 'dermat/o'.",
+              "derma": "'derma' is close, but the combining form used in medical terms is 'dermat/o' (root plus combining vowel).",
+              "derm-itis": "'derm-itis' mixes the root with the suffix. Split the term into its parts: 'dermat/o' (skin) + '-itis' (inflammation).",
+          },
+          Hints: []string{
+              "Split the term into a root and a suffix.",
+              "Medical roots are written as combining forms, with a slash before the combining vowel.",
+          },
       },
       {
           ID: 2,
@@ -44,9 +54,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"inflammation of", "study of", "removal of", "disease of"},
           Metadata: QuestionMetadata{
               Difficulty: 0.15,
+              IRT:        IRTParams{A: 1.0, B: -1.4, C: 0.2},
               Tags: []string{"suffix identification", "basicsuffixes"},
           },
           Feedback: "The suffix '-ology' means 'study of' and appears in many medical specialties like cardiology and dermatology. Don't confuse it with '-itis' (inflammation).",
+          OptionFeedback: map[string]string{
+              "inflammation of": "Inflammation is '-itis', as in dermatitis. '-ology' means study of, as in cardiology.",
+              "removal of": "Removal is '-ectomy', as in appendectomy. '-ology' means study of.",
+              "disease of": "Disease is usually '-pathy' or '-osis'. '-ology' means study of, as in dermatology.",
+          },
       },
       {
           ID: 3,
@@ -55,9 +71,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"inflammation of the heart", "study of the heart", "removal of the heart", "disease of the heart"},
           Metadata: QuestionMetadata{
               Difficulty: 0.2,
+              IRT:        IRTParams{A: 1.1, B: -1.2, C: 0.2},
               Tags: []string{"analogical reasoning", "suffix pattern", "cardiology"},
           },
           Feedback: "By changing '-ology' (study of) to '-itis' (inflammation), you transform the meaning. This pattern applies to many terms.",
+          OptionFeedback: map[string]string{
+              "study of the heart": "'Study of the heart' is cardiology ('-logy'). The '-itis' in 'carditis' means inflammation.",
+              "removal of the heart": "Removal would use '-ectomy'. The '-itis' in 'carditis' means inflammation.",
+              "disease of the heart": "Disease of the heart would be cardiopathy ('-pathy'). '-itis' is specifically inflammation.",
+          },
       },
       {
           ID:     4,
@@ -66,9 +88,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"-itis", "-logy", "-ectomy", "-osis"},
           Metadata: QuestionMetadata{
               Difficulty: 0.25,
+              IRT:        IRTParams{A: 0.9, B: -1.0, C: 0.2},
               Tags:       []string{"term construction", "suffix selection", "gastroenterology"},
           },
           Feedback: "When building medical terms, '-logy' creates the name of a specialty or field of study. Remember: gastr/o (stomach) + -logy = gastrology.",
+          OptionFeedback: map[string]string{
+              "-itis": "'gastritis' means inflammation of the stomach. Study of needs '-logy': gastrology.",
+              "-ectomy": "'gastrectomy' is removal of the stomach. Study of needs '-logy'.",
+              "-osis": "'-osis' means an abnormal condition. Study of needs '-logy'.",
+          },
       },
       {
           ID:     5,
@@ -77,9 +105,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"neph", "nephr/o", "-itis", "ren/o"},
           Metadata: QuestionMetadata{
               Difficulty: 0.3,
+              IRT:        IRTParams{A: 1.2, B: -0.8, C: 0.2},
               Tags:       []string{"root identification", "nephrology", "organ roots"},
           },
           Feedback: "The root 'nephr/o' means kidney and appears in terms like nephrology and nephron. Note that 'ren/o' also means kidney in Latin-derived terms.",
+          OptionFeedback: map[string]string{
+              "neph": "'neph' is missing the rest of the root. The combining form is 'nephr/o'.",
+              "-itis": "'-itis' is the suffix meaning inflammation. The root for kidney is 'nephr/o'.",
+              "ren/o": "'ren/o' also means kidney, but it comes from Latin and isn't the root used in 'nephritis'. Look for the part actually in the word: 'nephr/o'.",
+          },
       },
       {
           ID:     6,
@@ -88,9 +122,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"inflammation of the stomach and intestines", "study of the stomach and intestines", "inflammation of the stomach", "removal of the stomach and intestines"},
           Metadata: QuestionMetadata{
               Difficulty: 0.35,
+              IRT:        IRTParams{A: 1.0, B: -0.6, C: 0.2},
               Tags:       []string{"multi-part term", "meaning decomposition", "gastroenterology"},
           },
           Feedback: "This combines gastr/o (stomach), enter/o (intestines), and -itis (inflammation). Multi-root terms combine meanings additively.",
+          OptionFeedback: map[string]string{
+              "study of the stomach and intestines": "Study of would be '-logy'. The '-itis' at the end means inflammation.",
+              "inflammation of the stomach": "That's gastritis. 'enter/o' adds the intestines: gastr/o + enter/o + -itis.",
+              "removal of the stomach and intestines": "Removal would be '-ectomy'. The '-itis' at the end means inflammation.",
+          },
       },
       {
           ID:     7,
@@ -99,9 +139,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"below normal", "excessive, above normal","without", "around"},
           Metadata: QuestionMetadata{
               Difficulty: 0.4,
+              IRT:        IRTParams{A: 1.3, B: -0.4, C: 0.2},
               Tags:       []string{"prefix identification", "common prefixes"},
           },
           Feedback: "The prefix 'hyper-' means excessive or above normal, as in hypertension (high blood pressure). Its opposite is 'hypo-' (below normal).",
+          OptionFeedback: map[string]string{
+              "below normal": "Below normal is 'hypo-', the opposite of 'hyper-'. They differ by one letter, so read prefixes carefully.",
+              "without": "Without is 'a-' or 'an-', as in anemia. 'hyper-' means excessive or above normal.",
+              "around": "Around is 'peri-', as in pericardium. 'hyper-' means excessive or above normal.",
+          },
       },
       {
           ID:     8,
@@ -110,9 +156,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"inflammation of the liver", "study of the liver", "liver disease", "enlarged liver"},
           Metadata: QuestionMetadata{
               Difficulty: 0.4,
+              IRT:        IRTParams{A: 0.9, B: -0.4, C: 0.2},
               Tags:       []string{"analogical reasoning", "hepatology","organ roots"},
           },
           Feedback: "Apply the pattern: hepat/o (liver) + -itis (inflammation) = hepatitis. This is the same construction pattern as carditis and nephritis.",
+          OptionFeedback: map[string]string{
+              "study of the liver": "Study of the liver is hepatology ('-logy'). '-itis' means inflammation.",
+              "liver disease": "Liver disease in general is hepatopathy. '-itis' is specifically inflammation.",
+              "enlarged liver": "An enlarged liver is hepatomegaly ('-megaly'). '-itis' means inflammation.",
+          },
       },
       {
           ID:     9,
@@ -121,9 +173,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"-itis", "-ectomy", "-logy", "-plasty"},
           Metadata: QuestionMetadata{
               Difficulty: 0.45,
+              IRT:        IRTParams{A: 1.1, B: -0.2, C: 0.2},
               Tags:       []string{"term construction", "surgical suffix", "complex root"},
           },
           Feedback: "The suffix '-ectomy' means surgical removal. Combined with cholecyst/o (gallbladder), you get cholecystectomy—a common surgical procedure.",
+          OptionFeedback: map[string]string{
+              "-itis": "'cholecystitis' is inflammation of the gallbladder. Removal needs '-ectomy'.",
+              "-logy": "'-logy' means study of. Removal needs '-ectomy'.",
+              "-plasty": "'-plasty' means surgical repair, not removal. Removal is '-ectomy'.",
+          },
       },
       {
           ID:     10,
@@ -132,9 +190,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"brain", "head", "skull", "spinal cord"},
           Metadata: QuestionMetadata{
               Difficulty: 0.5,
+              IRT:        IRTParams{A: 1.4, B: 0.0, C: 0.2},
               Tags:       []string{"root identification", "neurology", "related anatomy confusion"},
           },
           Feedback: "The root 'encephal/o' specifically means brain, not head or skull. Encephalitis is inflammation of the brain tissue itself.",
+          OptionFeedback: map[string]string{
+              "head": "Head is 'cephal/o'. The 'en-' prefix means within, so 'encephal/o' is what's within the head: the brain.",
+              "skull": "Skull is 'crani/o'. 'encephal/o' means brain.",
+              "spinal cord": "Spinal cord is 'myel/o'. 'encephal/o' means brain.",
+          },
       },
       {
           ID:     11,
@@ -143,9 +207,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"arthritis is inflammation, arthralgia is pain", "arthritis is pain, arthralgia is inflammation", "both mean the same thing", "arthritis is chronic, arthralgia is acute"},
           Metadata: QuestionMetadata{
               Difficulty: 0.55,
+              IRT:        IRTParams{A: 1.2, B: 0.2, C: 0.2},
               Tags:       []string{"suffix distinction", "similar terms", "rheumatology"},
           },
           Feedback: "Both share arthr/o (joint), but -itis means inflammation while -algia means pain. Understanding suffix differences is crucial for precise medical communication.",
+          OptionFeedback: map[string]string{
+              "arthritis is pain, arthralgia is inflammation": "These are reversed. '-itis' is inflammation and '-algia' is pain.",
+              "both mean the same thing": "They share the root 'arthr/o' (joint) but have different suffixes: '-itis' is inflammation and '-algia' is pain.",
+              "arthritis is chronic, arthralgia is acute": "The suffixes describe what happens, not how long: '-itis' is inflammation and '-algia' is pain.",
+          },
       },
       {
           ID:     12,
@@ -154,9 +224,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"inflammation of the inner lining of the heart", "inflammation around the heart", "heart disease", "inflammation of the heart muscle"},
           Metadata: QuestionMetadata{
               Difficulty: 0.6,
+              IRT:        IRTParams{A: 1.0, B: 0.4, C: 0.2},
               Tags:       []string{"prefix + root + suffix", "multi-part construction", "cardiology"},
           },
           Feedback: "Combining prefix + root + suffix: endo- (within) + cardi/o (heart) + -itis (inflammation) = inflammation of the inner heart lining.",
+          OptionFeedback: map[string]string{
+              "inflammation around the heart": "Around is 'peri-' (pericarditis). 'endo-' means within, so it's the inner lining.",
+              "heart disease": "'-itis' is specifically inflammation, and 'endo-' narrows it to the inner lining of the heart.",
+              "inflammation of the heart muscle": "The heart muscle is 'my/o' (myocarditis). 'endo-' means within: the inner lining.",
+          },
       },
       {
           ID:     13,
@@ -165,9 +241,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"blood", "liver", "heart", "skin"},
           Metadata: QuestionMetadata{
               Difficulty: 0.55,
+              IRT:        IRTParams{A: 1.3, B: 0.2, C: 0.2},
               Tags:       []string{"specialty identification", "hemat/o root", "related concepts"},
           },
           Feedback: "The root 'hemat/o' or 'hem/o' means blood. Hematology is the medical specialty focused on blood disorders and diseases.",
+          OptionFeedback: map[string]string{
+              "liver": "Liver is 'hepat/o' (hepatology). 'hemat/o' means blood.",
+              "heart": "Heart is 'cardi/o' (cardiology). 'hemat/o' means blood.",
+              "skin": "Skin is 'dermat/o' (dermatology). 'hemat/o' means blood.",
+          },
       },
       {
           ID:     14,
@@ -176,9 +258,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"oste/o (bone) and arthr/o (joint)", "osteo (bone) and -itis (inflammation)", "oste/o (bone) and -itis (inflammation)", "oste (muscle) and arthr/o (joint)"},
           Metadata: QuestionMetadata{
               Difficulty: 0.65,
+              IRT:        IRTParams{A: 1.5, B: 0.6, C: 0.2},
               Tags:       []string{"multi-root term", "root identification", "structural analysis"},
           },
           Feedback: "Complex terms often combine multiple roots. Here: oste/o (bone) + arthr/o (joint) + -itis (inflammation) describes bone-joint inflammation.",
+          OptionFeedback: map[string]string{
+              "osteo (bone) and -itis (inflammation)": "'-itis' is a suffix, not a root. The second root is 'arthr/o' (joint).",
+              "oste/o (bone) and -itis (inflammation)": "'oste/o' is right, but '-itis' is a suffix, not a root. The second root is 'arthr/o' (joint).",
+              "oste (muscle) and arthr/o (joint)": "'oste/o' means bone, not muscle. Muscle is 'my/o'.",
+          },
       },
       {
           ID:     15,
@@ -187,9 +275,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"surgical removal", "surgical repair", "inflammation", "incision into"},
           Metadata: QuestionMetadata{
               Difficulty: 0.6,
+              IRT:        IRTParams{A: 1.1, B: 0.4, C: 0.2},
               Tags:       []string{"surgical suffix", "advanced suffix", "suffix distinction"},
           },
           Feedback: "The suffix '-plasty' means surgical repair or reconstruction, as in rhinoplasty (nose reshaping). Don't confuse with '-ectomy' (removal).",
+          OptionFeedback: map[string]string{
+              "surgical removal": "Surgical removal is '-ectomy'. '-plasty' means surgical repair, as in rhinoplasty.",
+              "inflammation": "Inflammation is '-itis'. '-plasty' means surgical repair.",
+              "incision into": "Incision into is '-otomy'. '-plasty' means surgical repair.",
+          },
       },
       {
           ID:     16,
@@ -198,9 +292,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"surgical removal of a lung", "inflammation of the lung", "study of the lungs", "surgical repair of a lung"},
           Metadata: QuestionMetadata{
               Difficulty: 0.7,
+              IRT:        IRTParams{A: 1.2, B: 0.8, C: 0.2},
               Tags:       []string{"term decomposition", "pulmonology", "surgical terminology"},
           },
           Feedback: "Apply the pattern: pneumon/o (lung) + -ectomy (removal) = pneumonectomy. This surgical term follows the standard construction pattern.",
+          OptionFeedback: map[string]string{
+              "inflammation of the lung": "Inflammation of the lung is pneumonitis ('-itis'). '-ectomy' means removal.",
+              "study of the lungs": "Study of would be '-logy'. '-ectomy' means surgical removal.",
+              "surgical repair of a lung": "Surgical repair is '-plasty'. '-ectomy' means surgical removal.",
+          },
       },
       {
           ID:     17,
@@ -209,9 +309,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"neuritis", "polyneuritis", "neuropathy", "multineuritis"},
           Metadata: QuestionMetadata{
               Difficulty: 0.75,
+              IRT:        IRTParams{A: 1.4, B: 1.0, C: 0.2},
               Tags:       []string{"prefix selection", "term construction", "neurology", "poly- prefix"},
           },
           Feedback: "The prefix 'poly-' means many or multiple. Combined with neur/o (nerve) + -itis (inflammation), polyneuritis describes multiple nerve inflammation.",
+          OptionFeedback: map[string]string{
+              "neuritis": "'neuritis' is inflammation of a nerve, but it's missing 'poly-' (many).",
+              "neuropathy": "'-pathy' means disease, not inflammation. Many nerves inflamed is 'poly-' + neur/o + '-itis'.",
+              "multineuritis": "'multi-' is Latin; medical terms pair Greek roots with the Greek prefix 'poly-' for many.",
+          },
       },
       {
           ID:     18,
@@ -220,9 +326,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"gallbladder, stone, condition of", "bile, stone, inflammation", "gallbladder, calcification, disease", "liver, stone, presence of"},
           Metadata: QuestionMetadata{
               Difficulty: 0.85,
+              IRT:        IRTParams{A: 1.6, B: 1.4, C: 0.2},
               Tags:       []string{"complex multi-part term", "three components", "gastroenterology"},
           },
           Feedback: "This complex term combines three parts: cholecyst/o (gallbladder) + lith/o (stone) + -iasis (condition). It means gallstones.",
+          OptionFeedback: map[string]string{
+              "bile, stone, inflammation": "Bile alone is 'chol/e'; 'cholecyst/o' adds 'cyst' (bladder): gallbladder. '-iasis' means condition of, not inflammation ('-itis').",
+              "gallbladder, calcification, disease": "'lith/o' means stone, and '-iasis' means condition of rather than disease in general.",
+              "liver, stone, presence of": "Liver is 'hepat/o'; 'cholecyst/o' is the gallbladder.",
+          },
       },
       {
           ID:     19,
@@ -231,9 +343,15 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"outer sac, heart muscle, inner lining", "heart muscle, inner lining, outer sac", "upper chamber, lower chamber, valve", "artery, vein, capillary"},
           Metadata: QuestionMetadata{
               Difficulty: 0.9,
+              IRT:        IRTParams{A: 1.3, B: 1.6, C: 0.2},
               Tags:       []string{"anatomical layers", "prefix distinction", "cardiology", "advanced"},
           },
           Feedback: "These prefixes indicate layers: peri- (around/outer), myo- (muscle), endo- (within/inner). Each describes a different layer of the heart.",
+          OptionFeedback: map[string]string{
+              "heart muscle, inner lining, outer sac": "The order is shifted. 'peri-' (around) is the outer sac, 'my/o' (muscle) the heart muscle, 'endo-' (within) the inner lining.",
+              "upper chamber, lower chamber, valve": "The chambers are the atria and ventricles. These terms are layers: 'peri-' around, 'my/o' muscle, 'endo-' within.",
+              "artery, vein, capillary": "Those are blood vessels. 'cardi/o' in each term points to the heart's layers: 'peri-' around, 'my/o' muscle, 'endo-' within.",
+          },
       },
       {
           ID:     20,
@@ -242,8 +360,108 @@ var medicalTerminologyQuestions = []Question{
           Options: []string{"imaging of bile ducts and pancreas", "study of liver and pancreas", "inflammation of bile ducts and pancreas", "removal of gallbladder and pancreas"},
           Metadata: QuestionMetadata{
               Difficulty: 0.95,
+              IRT:        IRTParams{A: 1.5, B: 1.8, C: 0.2},
               Tags:       []string{"highly complex term", "diagnostic procedure", "multi-root construction", "advanced"},
           },
           Feedback: "This advanced term combines cholangi/o (bile ducts) + pancreat/o (pancreas) + -graphy (recording/imaging). ERCP is a common abbreviation.",
+          OptionFeedback: map[string]string{
+              "study of liver and pancreas": "There's no 'hepat/o' (liver) here, and '-graphy' means recording or imaging, not study of.",
+              "inflammation of bile ducts and pancreas": "There's no '-itis'. '-graphy' means recording or imaging.",
+              "removal of gallbladder and pancreas": "There's no '-ectomy', and 'cholangi/o' means bile duct, not gallbladder. '-graphy' means imaging.",
+          },
+      },
+      {
+          ID:     21,
+          Type:   FreeText,
+          Text:   "Spell the term for 'inflammation of the kidney'.",
+          Answer: "nephritis",
+          MaxEdits: 2,
+          Metadata: QuestionMetadata{
+              Difficulty: 0.35,
+              IRT:        IRTParams{A: 1.2, B: -0.6, C: 0},
+              Tags:       []string{"term construction", "suffix selection", "nephrology"},
+          },
+          Feedback: "nephr/o (kidney) + -itis (inflammation) = nephritis. The combining vowel 'o' drops before a suffix that starts with a vowel.",
+          Hints: []string{
+              "The term is a kidney root followed by the suffix for inflammation.",
+              "The kidney root is 'nephr/o' and the suffix is '-itis'.",
+              "Drop the combining vowel before '-itis': nephr + itis.",
+          },
+      },
+      {
+          ID:      22,
+          Type:    MultiSelect,
+          Text:    "Select every suffix that names a surgical procedure.",
+          Answer:  "-ectomy, -plasty, -otomy",
+          Answers: []string{"-ectomy", "-plasty", "-otomy"},
+          Options: []string{"-ectomy", "-itis", "-plasty", "-algia", "-otomy"},
+          Metadata: QuestionMetadata{
+              Difficulty: 0.5,
+              IRT:        IRTParams{A: 1.3, B: 0, C: 0.05},
+              Tags:       []string{"surgical suffix", "suffix distinction"},
+          },
+          Feedback: "-ectomy (removal), -plasty (repair) and -otomy (incision into) are procedures. -itis (inflammation) and -algia (pain) describe conditions.",
+          Hints: []string{
+              "Procedures are things a surgeon does; conditions are things a patient has.",
+              "Two of the options describe conditions: inflammation and pain.",
+          },
+      },
+      {
+          ID:      23,
+          Type:    Ordering,
+          Text:    "Put the word parts in order to build the term for 'inflammation of the stomach and intestines'.",
+          Answer:  "gastr/o + enter/o + -itis",
+          Answers: []string{"gastr/o", "enter/o", "-itis"},
+          Options: []string{"-itis", "gastr/o", "enter/o"},
+          Metadata: QuestionMetadata{
+              Difficulty: 0.45,
+              IRT:        IRTParams{A: 1.2, B: -0.2, C: 0.15},
+              Tags:       []string{"multi-part construction", "prefix + root + suffix", "gastroenterology"},
+          },
+          Feedback: "Roots come first in the order the body parts are named, then the suffix: gastr/o + enter/o + -itis = gastroenteritis.",
+          Hints: []string{
+              "The suffix always comes last.",
+              "Name the stomach before the intestines.",
+          },
+      },
+      {
+          ID:      24,
+          Type:    TermBuilding,
+          Text:    "Build the term for 'inflammation of a joint': ___ + ___",
+          Answer:  "arthr/o + -itis",
+          Answers: []string{"arthr/o", "-itis"},
+          Synonyms: map[string][]string{"arthr/o": {"arthr"}},
+          MaxEdits: 1,
+          Metadata: QuestionMetadata{
+              Difficulty: 0.4,
+              IRT:        IRTParams{A: 1.1, B: -0.4, C: 0},
+              Tags:       []string{"term construction", "suffix selection", "rheumatology"},
+          },
+          Feedback: "arthr/o (joint) + -itis (inflammation) = arthritis.",
+          Hints: []string{
+              "Start with the root for joint, then add the suffix for inflammation.",
+              "The joint root is the one in 'arthroscopy'; the inflammation suffix is the one in 'dermatitis'.",
+          },
+      },
+      {
+          ID:     25,
+          Type:   Explanation,
+          Text:   "Explain why 'hepatomegaly' means enlarged liver.",
+          Answer: "hepat/o means liver and the suffix -megaly means enlargement, so together they name an enlarged liver.",
+          Rubric: []RubricCriterion{
+              {ID: "root", Description: "Identifies hepat/o as the root meaning liver", Points: 1},
+              {ID: "suffix", Description: "Identifies -megaly as the suffix meaning enlargement", Points: 1},
+              {ID: "combination", Description: "Explains that the root names the organ and the suffix says what is happening to it", Points: 1},
+          },
+          Metadata: QuestionMetadata{
+              Difficulty: 0.8,
+              IRT:        IRTParams{A: 1.2, B: 1.2, C: 0},
+              Tags:       []string{"term decomposition", "meaning decomposition", "hepatology"},
+          },
+          Feedback: "hepat/o (liver) + -megaly (enlargement) = hepatomegaly. Reading the root first tells you the organ; the suffix tells you what is happening to it.",
+          Hints: []string{
+              "Split the term into a root and a suffix.",
+              "The root is the one in 'hepatitis'; the suffix is the one in 'cardiomegaly'.",
+          },
       },
-  }
\ No newline at end of file
+  }
@@ -0,0 +1,379 @@
+package content
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TemplateBank generates multiple-choice questions from a lexicon: what a word
+// part means, which part has a meaning, which part of a term carries a meaning,
+// and what a term means. Distractors are drawn from the lexicon, preferring the
+// most confusable entries. Each question is built when first asked for, from
+// its ID and the bank's seed alone, so the same seed always yields the same questions.
+// Every template and lexicon entry it applies to (an item) is served in as many
+// variants as the bank was built with: the same stem with its own seeded draw of
+// distractors and option order, so the bank grows with the variant count up to
+// TemplateIDLimit rather than only with the lexicon. Variants are separate
+// questions, so a long session can meet a stem again with different options.
+type TemplateBank struct {
+	lexicon  *Lexicon
+	seed     int64
+	idBase   int
+	variants int
+	items    []templateItem
+
+	mu    sync.Mutex
+	built []*Question // Questions built so far, by index
+}
+
+type templateKind int
+
+const (
+	partMeaning    templateKind = iota // What does the root 'cardi/o' mean?
+	partForMeaning                     // Which root means 'heart'?
+	partInTerm                         // In 'cardiology', which part means 'heart'?
+	termMeaning                        // What does 'cardiology' mean?
+)
+
+// templateItem is one question the bank can build: a template applied to a
+// lexicon part or to one part of a term
+type templateItem struct {
+	kind templateKind
+	part string // Word part form, for part templates
+	term int    // Index into the lexicon's terms, for term templates
+}
+
+const templateOptionCount = 4
+
+// TemplateIDBase keeps templated question IDs clear of the static bank's
+const TemplateIDBase = 1000
+
+// TemplateIDLimit is the highest ID a templated question may have; IDs above
+// it are reserved for approved drafts
+const TemplateIDLimit = 100000
+
+// NewTemplateBank numbers its questions from idBase + 1: the first variant of
+// every item, then the second, and so on. idBase must leave room below for any
+// bank it is combined with, and every ID must fit under TemplateIDLimit.
+func NewTemplateBank(lexicon *Lexicon, seed int64, idBase int, variants int) (*TemplateBank, error) {
+	if err := lexicon.Validate(); err != nil {
+		return nil, err
+	}
+	if variants < 1 {
+		return nil, fmt.Errorf("template variants must be at least 1, got %d", variants)
+	}
+	tb := &TemplateBank{lexicon: lexicon, seed: seed, idBase: idBase, variants: variants}
+	for _, part := range lexicon.Parts {
+		tb.items = append(tb.items,
+			templateItem{kind: partMeaning, part: part.Form},
+			templateItem{kind: partForMeaning, part: part.Form})
+	}
+	for i, term := range lexicon.Terms {
+		tb.items = append(tb.items, templateItem{kind: termMeaning, term: i})
+		for _, form := range term.Parts {
+			tb.items = append(tb.items, templateItem{kind: partInTerm, part: form, term: i})
+		}
+	}
+	if last := idBase + variants*len(tb.items); last > TemplateIDLimit {
+		return nil, fmt.Errorf("%d variants of %d items would number questions up to %d, past %d",
+			variants, len(tb.items), last, TemplateIDLimit)
+	}
+	tb.built = make([]*Question, tb.Len())
+	return tb, nil
+}
+
+// Len is the number of questions in the bank, every variant of every item
+func (tb *TemplateBank) Len() int {
+	return len(tb.items) * tb.variants
+}
+
+func (tb *TemplateBank) GetAll() ([]Question, error) {
+	questions := make([]Question, tb.Len())
+	for i := range questions {
+		questions[i] = *tb.question(i)
+	}
+	return questions, nil
+}
+
+func (tb *TemplateBank) GetQuestionByID(id int) (*Question, error) {
+	index := id - tb.idBase - 1
+	if index < 0 || index >= tb.Len() {
+		return nil, fmt.Errorf("question ID %d not found", id)
+	}
+	return tb.question(index), nil
+}
+
+// question builds the question at index the first time it is asked for
+func (tb *TemplateBank) question(index int) *Question {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	if tb.built[index] == nil {
+		q := tb.build(index)
+		tb.built[index] = &q
+	}
+	return tb.built[index]
+}
+
+func (tb *TemplateBank) build(index int) Question {
+	// Each question has its own stream, so building one never changes another,
+	// and each variant of an item draws its own distractors
+	rng := rand.New(rand.NewSource(tb.seed*1_000_003 + int64(index)))
+	item := tb.items[index%len(tb.items)]
+
+	var q Question
+	switch item.kind {
+	case partMeaning, partForMeaning:
+		part, _ := tb.lexicon.Part(item.part)
+		q = tb.buildPartQuestion(rng, item.kind, part)
+	case partInTerm:
+		part, _ := tb.lexicon.Part(item.part)
+		q = tb.buildPartInTerm(rng, tb.lexicon.Terms[item.term], part)
+	case termMeaning:
+		q = tb.buildTermMeaning(rng, tb.lexicon.Terms[item.term])
+	}
+
+	q.ID = tb.idBase + index + 1
+	rng.Shuffle(len(q.Options), func(i, j int) { q.Options[i], q.Options[j] = q.Options[j], q.Options[i] })
+	q.Metadata.IRT = IRTParams{A: 1.0, B: (q.Metadata.Difficulty - 0.5) * 4, C: 1 / float64(len(q.Options))}
+	return q
+}
+
+func (tb *TemplateBank) buildPartQuestion(rng *rand.Rand, kind templateKind, part WordPart) Question {
+	distractors := tb.pickParts(rng, part, func(p WordPart) bool {
+		return part.BodySystem != "" && p.BodySystem == part.BodySystem
+	})
+
+	q := Question{
+		OptionFeedback: make(map[string]string),
+		Metadata: QuestionMetadata{
+			Difficulty: partDifficulty[part.Kind],
+			Tags:       partTags(part),
+		},
+	}
+	if kind == partMeaning {
+		q.Text = fmt.Sprintf("What does the %s '%s' mean?", part.Kind, part.Form)
+		q.Answer = part.Meaning
+		for _, d := range distractors {
+			q.OptionFeedback[d.Meaning] = fmt.Sprintf("'%s' means %s; '%s' means %s.", d.Form, d.Meaning, part.Form, part.Meaning)
+		}
+	} else {
+		q.Text = fmt.Sprintf("Which %s means '%s'?", part.Kind, part.Meaning)
+		q.Answer = part.Form
+		q.Metadata.Difficulty += 0.1
+		for _, d := range distractors {
+			q.OptionFeedback[d.Form] = fmt.Sprintf("'%s' means %s, not %s.", d.Form, d.Meaning, part.Meaning)
+		}
+	}
+	q.Options = append([]string{q.Answer}, optionKeys(q.OptionFeedback)...)
+
+	q.Feedback = fmt.Sprintf("'%s' means %s.", part.Form, part.Meaning)
+	q.Hints = []string{kindHint(part)}
+	if term, ok := tb.termUsing(part.Form); ok {
+		q.Feedback += fmt.Sprintf(" For example, %s: %s.", term.Word, tb.breakdown(term))
+		if kind == partMeaning {
+			q.Hints = append(q.Hints, fmt.Sprintf("It appears in %s, which means %s.", term.Word, term.Meaning))
+		} else {
+			q.Hints = append(q.Hints, fmt.Sprintf("It appears in %s.", term.Word))
+		}
+	}
+	return q
+}
+
+func (tb *TemplateBank) buildPartInTerm(rng *rand.Rand, term Term, part WordPart) Question {
+	// The term's other parts are the most tempting wrong answers
+	distractors := tb.pickParts(rng, part, func(p WordPart) bool {
+		for _, form := range term.Parts {
+			if p.Form == form {
+				return true
+			}
+		}
+		return false
+	})
+
+	q := Question{
+		Text:     fmt.Sprintf("In '%s', which part means '%s'?", term.Word, part.Meaning),
+		Answer:   part.Form,
+		Feedback: fmt.Sprintf("%s = %s.", term.Word, tb.breakdown(term)),
+		Hints: []string{
+			fmt.Sprintf("Split '%s' into its parts: %s.", term.Word, strings.Join(term.Parts, " + ")),
+			tb.meanings(term, part.Form),
+		},
+		OptionFeedback: make(map[string]string),
+		Metadata: QuestionMetadata{
+			Difficulty: 0.3 + 0.15*float64(len(term.Parts)-2),
+			Tags:       append(partTags(part), "term decomposition"),
+		},
+	}
+	for _, d := range distractors {
+		q.OptionFeedback[d.Form] = fmt.Sprintf("'%s' means %s.", d.Form, d.Meaning)
+	}
+	q.Options = append([]string{q.Answer}, optionKeys(q.OptionFeedback)...)
+	return q
+}
+
+func (tb *TemplateBank) buildTermMeaning(rng *rand.Rand, term Term) Question {
+	// Terms sharing a part with this one are the most confusable
+	var shared, others []Term
+	for _, other := range tb.lexicon.Terms {
+		if other.Word == term.Word || other.Meaning == term.Meaning {
+			continue
+		}
+		if sharesPart(term, other) {
+			shared = append(shared, other)
+		} else {
+			others = append(others, other)
+		}
+	}
+	rng.Shuffle(len(shared), func(i, j int) { shared[i], shared[j] = shared[j], shared[i] })
+	rng.Shuffle(len(others), func(i, j int) { others[i], others[j] = others[j], others[i] })
+	candidates := append(shared, others...)
+
+	q := Question{
+		Text:     fmt.Sprintf("What does '%s' mean?", term.Word),
+		Answer:   term.Meaning,
+		Feedback: fmt.Sprintf("%s = %s.", term.Word, tb.breakdown(term)),
+		Hints: []string{
+			fmt.Sprintf("Split '%s' into its parts: %s.", term.Word, strings.Join(term.Parts, " + ")),
+			tb.meanings(term, ""),
+		},
+		OptionFeedback: make(map[string]string),
+		Metadata: QuestionMetadata{
+			Difficulty: 0.45 + 0.15*float64(len(term.Parts)-2),
+			Tags:       []string{"meaning decomposition"},
+		},
+	}
+	if len(term.Parts) > 2 {
+		q.Metadata.Tags = append(q.Metadata.Tags, "three components")
+	}
+	if first, _ := tb.lexicon.Part(term.Parts[0]); first.Kind == Prefix {
+		q.Metadata.Tags = append(q.Metadata.Tags, "prefix + root + suffix")
+	}
+	if system := tb.bodySystem(term); system != "" {
+		q.Metadata.Tags = append(q.Metadata.Tags, system)
+	}
+	for _, other := range candidates[:min(len(candidates), templateOptionCount-1)] {
+		q.OptionFeedback[other.Meaning] = fmt.Sprintf("That's %s: %s.", other.Word, tb.breakdown(other))
+	}
+	q.Options = append([]string{q.Answer}, optionKeys(q.OptionFeedback)...)
+	return q
+}
+
+// pickParts draws distractors of the same kind as part with different
+// meanings, taking preferred parts first
+func (tb *TemplateBank) pickParts(rng *rand.Rand, part WordPart, preferred func(WordPart) bool) []WordPart {
+	var first, rest []WordPart
+	for _, p := range tb.lexicon.Parts {
+		if p.Form == part.Form || p.Meaning == part.Meaning {
+			continue
+		}
+		if preferred(p) {
+			first = append(first, p)
+		} else if p.Kind == part.Kind {
+			rest = append(rest, p)
+		}
+	}
+	rng.Shuffle(len(first), func(i, j int) { first[i], first[j] = first[j], first[i] })
+	rng.Shuffle(len(rest), func(i, j int) { rest[i], rest[j] = rest[j], rest[i] })
+	picked := append(first, rest...)
+	return picked[:min(len(picked), templateOptionCount-1)]
+}
+
+// breakdown renders a term as its parts and their meanings, e.g. "hepat/o (liver) + -itis (inflammation)"
+func (tb *TemplateBank) breakdown(term Term) string {
+	parts := make([]string, len(term.Parts))
+	for i, form := range term.Parts {
+		part, _ := tb.lexicon.Part(form)
+		parts[i] = fmt.Sprintf("%s (%s)", part.Form, part.Meaning)
+	}
+	return strings.Join(parts, " + ")
+}
+
+// meanings lists what each part of a term means, leaving out the part whose
+// meaning is being asked for
+func (tb *TemplateBank) meanings(term Term, except string) string {
+	var parts []string
+	for _, form := range term.Parts {
+		if form == except {
+			continue
+		}
+		part, _ := tb.lexicon.Part(form)
+		parts = append(parts, fmt.Sprintf("'%s' means %s", part.Form, part.Meaning))
+	}
+	return strings.Join(parts, ", ") + "."
+}
+
+func (tb *TemplateBank) termUsing(form string) (Term, bool) {
+	for _, term := range tb.lexicon.Terms {
+		for _, f := range term.Parts {
+			if f == form {
+				return term, true
+			}
+		}
+	}
+	return Term{}, false
+}
+
+func (tb *TemplateBank) bodySystem(term Term) string {
+	for _, form := range term.Parts {
+		if part, _ := tb.lexicon.Part(form); part.BodySystem != "" {
+			return part.BodySystem
+		}
+	}
+	return ""
+}
+
+// kindHint says what kind of part a word part is, without giving its meaning
+func kindHint(part WordPart) string {
+	switch {
+	case part.BodySystem != "":
+		return fmt.Sprintf("It's a %s from the %s system.", part.Kind, part.BodySystem)
+	case part.Kind == Prefix:
+		return "It's a prefix; prefixes often describe position, amount or speed."
+	default:
+		return "It's a suffix; suffixes often name a condition, procedure or specialty."
+	}
+}
+
+var partDifficulty = map[WordPartKind]float64{Root: 0.15, Suffix: 0.2, Prefix: 0.25}
+
+// partTags maps a word part onto the skill graph's tags
+func partTags(part WordPart) []string {
+	var tags []string
+	switch part.Kind {
+	case Root:
+		tags = []string{"root identification", "organ roots"}
+	case Suffix:
+		tags = []string{"suffix identification"}
+	case Prefix:
+		tags = []string{"prefix identification", "common prefixes"}
+	}
+	if part.BodySystem != "" {
+		tags = append(tags, part.BodySystem)
+	}
+	return tags
+}
+
+func sharesPart(a, b Term) bool {
+	for _, x := range a.Parts {
+		for _, y := range b.Parts {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// optionKeys returns the distractors recorded in option feedback, sorted so
+// the seeded shuffle that follows always starts from the same order
+func optionKeys(feedback map[string]string) []string {
+	keys := make([]string, 0, len(feedback))
+	for k := range feedback {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,93 @@
+package content
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func distractors(q Question) []string {
+	var wrong []string
+	for _, option := range q.Options {
+		if option != q.Answer {
+			wrong = append(wrong, option)
+		}
+	}
+	sort.Strings(wrong)
+	return wrong
+}
+
+func TestTemplateBankVariants(t *testing.T) {
+	single, err := NewTemplateBank(MedicalLexicon(), 7, TemplateIDBase, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const variants = 3
+	bank, err := NewTemplateBank(MedicalLexicon(), 7, TemplateIDBase, variants)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := single.Len()
+	if bank.Len() != variants*items {
+		t.Fatalf("Len = %d with %d variants of %d items", bank.Len(), variants, items)
+	}
+
+	questions, err := bank.GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateQuestions(questions); err != nil {
+		t.Fatal(err)
+	}
+	base, _ := single.GetAll()
+	if !reflect.DeepEqual(questions[:items], base) {
+		t.Error("adding variants changed the first variant's questions")
+	}
+
+	// Later variants keep the stem and answer but draw their own distractors,
+	// unless the lexicon has too few candidates to differ
+	differ := 0
+	for i, q := range base {
+		for v := 1; v < variants; v++ {
+			variant := questions[v*items+i]
+			if variant.ID != q.ID+v*items || variant.Text != q.Text || variant.Answer != q.Answer {
+				t.Fatalf("variant %d of question %d is %d %q (%s), want the same stem and answer", v, q.ID, variant.ID, variant.Text, variant.Answer)
+			}
+			if !reflect.DeepEqual(distractors(variant), distractors(q)) {
+				differ++
+			}
+		}
+	}
+	if total := (variants - 1) * items; differ < total/2 {
+		t.Errorf("only %d of %d later variants have different distractors", differ, total)
+	}
+
+	last := TemplateIDBase + bank.Len()
+	if q, err := bank.GetQuestionByID(last); err != nil || !reflect.DeepEqual(*q, questions[len(questions)-1]) {
+		t.Errorf("GetQuestionByID(%d) = %v, %v; want the last variant", last, q, err)
+	}
+	if _, err := bank.GetQuestionByID(last + 1); err == nil {
+		t.Errorf("GetQuestionByID(%d) found a question past the last variant", last+1)
+	}
+}
+
+func TestTemplateBankVariantLimits(t *testing.T) {
+	single, err := NewTemplateBank(MedicalLexicon(), 1, TemplateIDBase, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	most := (TemplateIDLimit - TemplateIDBase) / single.Len()
+	tests := []struct {
+		variants int
+		ok       bool
+	}{
+		{0, false},
+		{most, true},
+		{most + 1, false},
+	}
+	for _, tt := range tests {
+		if _, err := NewTemplateBank(MedicalLexicon(), 1, TemplateIDBase, tt.variants); (err == nil) != tt.ok {
+			t.Errorf("%d variants: err = %v, want ok %v", tt.variants, err, tt.ok)
+		}
+	}
+}
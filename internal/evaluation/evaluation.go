@@ -0,0 +1,245 @@
+package evaluation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"go-adapt/internal/bkt"
+	"io"
+	"math/rand"
+	"sort"
+)
+
+// Offline knowledge-tracing evaluation: replay logged answer sequences through
+// a model, predicting each answer before the model sees it.
+
+// Answer is one logged answer
+type Answer struct {
+	Type       string `json:"type,omitempty"` // Set when read from an event log; only answers are kept
+	LearnerID  string `json:"learner_id"`
+	SessionID  string `json:"session_id"`
+	QuestionID int    `json:"question_id"`
+	UserAnswer string `json:"user_answer,omitempty"`
+	Correct    bool   `json:"correct"`
+	// Options in the order they were displayed, if logged. In an event log
+	// they come from the question_served event before the answer.
+	Options []string `json:"options,omitempty"`
+}
+
+const (
+	answerEventType  = "answer_submitted"
+	sessionEventType = "session_started"
+	servedEventType  = "question_served"
+)
+
+// LoadAnswers reads JSON Lines answer records in log order. Lines from an event
+// log that aren't answer events are skipped; session_started events supply the
+// learner ID for their session's answers, and question_served events the
+// displayed option order. Answers without a learner ID are attributed to their
+// session.
+func LoadAnswers(r io.Reader) ([]Answer, error) {
+	var answers []Answer
+	learners := make(map[string]string) // session ID -> learner ID
+	type served struct {
+		session  string
+		question int
+	}
+	displayed := make(map[served][]string) // Latest order each question was shown in
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var a Answer
+		if err := json.Unmarshal(scanner.Bytes(), &a); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if a.Type == sessionEventType && a.LearnerID != "" {
+			learners[a.SessionID] = a.LearnerID
+		}
+		if a.Type == servedEventType {
+			displayed[served{a.SessionID, a.QuestionID}] = a.Options
+		}
+		if a.Type != "" && a.Type != answerEventType {
+			continue
+		}
+		if a.Options == nil {
+			a.Options = displayed[served{a.SessionID, a.QuestionID}]
+		}
+		if a.LearnerID == "" {
+			a.LearnerID = learners[a.SessionID]
+		}
+		if a.LearnerID == "" {
+			a.LearnerID = a.SessionID
+		}
+		answers = append(answers, a)
+	}
+	return answers, scanner.Err()
+}
+
+// Model is a knowledge-tracing model for one learner on one skill
+type Model interface {
+	PredictCorrect() float64
+	Update(correct bool)
+}
+
+// ModelFactory returns a fresh model for a skill
+type ModelFactory func(skill string) Model
+
+// Fitter trains a ModelFactory from per-skill answer sequences
+type Fitter func(train map[string][][]bool) ModelFactory
+
+type bktModel struct {
+	*bkt.BKTModel
+}
+
+func (m bktModel) Update(correct bool) {
+	if correct {
+		m.UpdateCorrect()
+	} else {
+		m.UpdateIncorrect()
+	}
+}
+
+// BKTFactory uses the same BKT parameters for every skill
+func BKTFactory(l0, t, s, g float64) ModelFactory {
+	return func(string) Model {
+		return bktModel{bkt.InitializeBKTModel(l0, t, s, g)}
+	}
+}
+
+// FitBKT fits BKT parameters per skill
+func FitBKT(train map[string][][]bool) ModelFactory {
+	params := make(map[string][4]float64, len(train))
+	for skill, seqs := range train {
+		l0, t, s, g := bkt.Fit(seqs)
+		params[skill] = [4]float64{l0, t, s, g}
+	}
+	return func(skill string) Model {
+		p, ok := params[skill]
+		if !ok {
+			l0, t, s, g := bkt.Fit(nil)
+			p = [4]float64{l0, t, s, g}
+		}
+		return bktModel{bkt.InitializeBKTModel(p[0], p[1], p[2], p[3])}
+	}
+}
+
+// Sequences maps skill -> learner -> answers in log order. An answer counts
+// toward every skill its question exercises; skillsOf may return a single
+// catch-all skill to evaluate without a skill model.
+type Sequences map[string]map[string][]bool
+
+func GroupSequences(answers []Answer, skillsOf func(questionID int) []string) Sequences {
+	seqs := make(Sequences)
+	for _, a := range answers {
+		for _, skill := range skillsOf(a.QuestionID) {
+			if seqs[skill] == nil {
+				seqs[skill] = make(map[string][]bool)
+			}
+			seqs[skill][a.LearnerID] = append(seqs[skill][a.LearnerID], a.Correct)
+		}
+	}
+	return seqs
+}
+
+// Result holds scores pooled over all skills and per skill
+type Result struct {
+	Overall  Scores            `json:"overall"`
+	PerSkill map[string]Scores `json:"per_skill"`
+	Folds    int               `json:"folds,omitempty"`
+}
+
+// Evaluate replays every sequence through models from factory
+func Evaluate(seqs Sequences, factory ModelFactory) Result {
+	perSkill := make(map[string][]Prediction)
+	for skill, learners := range seqs {
+		for _, seq := range learners {
+			perSkill[skill] = append(perSkill[skill], replay(factory(skill), seq)...)
+		}
+	}
+	return result(perSkill, 0)
+}
+
+// CrossValidate splits learners into k folds. Each fold is predicted by models
+// fitted on the other folds, so no learner is scored by parameters trained on
+// their own answers. Predictions from all folds are pooled before scoring.
+func CrossValidate(seqs Sequences, k int, fit Fitter, seed int64) (Result, error) {
+	learners := learnerIDs(seqs)
+	if k < 2 || k > len(learners) {
+		return Result{}, fmt.Errorf("need 2 <= folds <= learners (%d), got %d", len(learners), k)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(learners), func(i, j int) {
+		learners[i], learners[j] = learners[j], learners[i]
+	})
+	fold := make(map[string]int, len(learners))
+	for i, id := range learners {
+		fold[id] = i % k
+	}
+
+	perSkill := make(map[string][]Prediction)
+	for f := 0; f < k; f++ {
+		train := make(map[string][][]bool)
+		for skill, bySkill := range seqs {
+			for id, seq := range bySkill {
+				if fold[id] != f {
+					train[skill] = append(train[skill], seq)
+				}
+			}
+		}
+		factory := fit(train)
+
+		for skill, bySkill := range seqs {
+			for id, seq := range bySkill {
+				if fold[id] == f {
+					perSkill[skill] = append(perSkill[skill], replay(factory(skill), seq)...)
+				}
+			}
+		}
+	}
+	return result(perSkill, k), nil
+}
+
+// replay predicts each answer before updating the model with it
+func replay(model Model, seq []bool) []Prediction {
+	predictions := make([]Prediction, 0, len(seq))
+	for _, correct := range seq {
+		predictions = append(predictions, Prediction{P: model.PredictCorrect(), Correct: correct})
+		model.Update(correct)
+	}
+	return predictions
+}
+
+func result(perSkill map[string][]Prediction, folds int) Result {
+	r := Result{
+		PerSkill: make(map[string]Scores, len(perSkill)),
+		Folds:    folds,
+	}
+	var all []Prediction
+	for skill, predictions := range perSkill {
+		r.PerSkill[skill] = Score(predictions)
+		all = append(all, predictions...)
+	}
+	r.Overall = Score(all)
+	return r
+}
+
+func learnerIDs(seqs Sequences) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, bySkill := range seqs {
+		for id := range bySkill {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,255 @@
+package evaluation
+
+import (
+	"go-adapt/internal/content"
+	"math"
+	"sort"
+)
+
+// Classical item analysis: how often each option is chosen and whether getting
+// a question right goes with doing well on the rest of the bank.
+
+// OptionStats is how often one answer option was chosen
+type OptionStats struct {
+	Option     string  `json:"option"`
+	Correct    bool    `json:"correct"`
+	Count      int     `json:"count"`
+	Proportion float64 `json:"proportion"`
+}
+
+// ItemStats is the analysis of a single question
+type ItemStats struct {
+	QuestionID int `json:"question_id"`
+	N          int `json:"n"`
+	// PValue is the proportion answered correctly (classical item difficulty)
+	PValue float64 `json:"p_value"`
+	// PointBiserial correlates answering this question correctly with each
+	// learner's proportion correct on the other questions. It is 0 when
+	// undefined: everyone right, everyone wrong, or no spread in rest scores.
+	PointBiserial     float64       `json:"point_biserial"`
+	Options           []OptionStats `json:"options"`
+	UnusedDistractors []string      `json:"unused_distractors"`
+	// Unrecorded counts answers without a chosen option, e.g. from older logs
+	Unrecorded int `json:"unrecorded"`
+	// Unmatched counts chosen answers that aren't one of the question's options
+	Unmatched int `json:"unmatched"`
+}
+
+// AnalyzeItems reports every question in the bank, in bank order, including
+// questions nobody answered. Answers to questions outside the bank are ignored.
+// Option statistics are only kept for multiple-choice questions.
+func AnalyzeItems(answers []Answer, questions []content.Question) []ItemStats {
+	// Per-learner totals, so each response can be compared to the learner's rest score
+	type tally struct{ correct, total int }
+	learnerTotals := make(map[string]tally)
+	learnerItem := make(map[string]map[int]tally)
+	byQuestion := make(map[int][]Answer)
+	for _, a := range answers {
+		t := learnerTotals[a.LearnerID]
+		t.total++
+		if a.Correct {
+			t.correct++
+		}
+		learnerTotals[a.LearnerID] = t
+
+		if learnerItem[a.LearnerID] == nil {
+			learnerItem[a.LearnerID] = make(map[int]tally)
+		}
+		it := learnerItem[a.LearnerID][a.QuestionID]
+		it.total++
+		if a.Correct {
+			it.correct++
+		}
+		learnerItem[a.LearnerID][a.QuestionID] = it
+
+		byQuestion[a.QuestionID] = append(byQuestion[a.QuestionID], a)
+	}
+
+	items := make([]ItemStats, 0, len(questions))
+	for _, q := range questions {
+		item := ItemStats{QuestionID: q.ID}
+		counts := make(map[string]int, len(q.Options))
+		var scores, rest []float64
+		correct := 0
+
+		choice := q.QuestionType() == content.MultipleChoice
+		for _, a := range byQuestion[q.ID] {
+			item.N++
+			score := 0.0
+			if a.Correct {
+				correct++
+				score = 1
+			}
+
+			switch {
+			case !choice:
+			case a.UserAnswer == "":
+				item.Unrecorded++
+			case containsOption(q.Options, a.UserAnswer):
+				counts[a.UserAnswer]++
+			default:
+				item.Unmatched++
+			}
+
+			// Rest score excludes every answer this learner gave to this question
+			t, it := learnerTotals[a.LearnerID], learnerItem[a.LearnerID][q.ID]
+			if others := t.total - it.total; others > 0 {
+				scores = append(scores, score)
+				rest = append(rest, float64(t.correct-it.correct)/float64(others))
+			}
+		}
+
+		if item.N > 0 {
+			item.PValue = float64(correct) / float64(item.N)
+		}
+		item.PointBiserial = pearson(scores, rest)
+
+		recorded := item.N - item.Unrecorded
+		item.UnusedDistractors = []string{}
+		if choice {
+			for _, option := range q.Options {
+				stats := OptionStats{Option: option, Correct: option == q.Answer, Count: counts[option]}
+				if recorded > 0 {
+					stats.Proportion = float64(stats.Count) / float64(recorded)
+				}
+				item.Options = append(item.Options, stats)
+				if !stats.Correct && stats.Count == 0 {
+					item.UnusedDistractors = append(item.UnusedDistractors, option)
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// PositionStats pools multiple-choice answers by where options were displayed.
+// Without position bias, ChosenRate is about one over the number of options at
+// every position and KeyPValue doesn't depend on where the correct option sat.
+type PositionStats struct {
+	Position   int     `json:"position"`    // 1-based display position
+	Shown      int     `json:"shown"`       // Answers with a recorded choice to questions showing an option here
+	Chosen     int     `json:"chosen"`      // Answers choosing the option shown here
+	ChosenRate float64 `json:"chosen_rate"` // Chosen / Shown
+	KeyShown   int     `json:"key_shown"`   // Answers to questions with the correct option shown here
+	KeyPValue  float64 `json:"key_p_value"` // Proportion correct when the correct option was shown here
+}
+
+// AnalyzePositions reports answer choices by display position. Answers
+// without a logged order are taken to have seen the options as authored,
+// which is how they were shown before options were shuffled.
+func AnalyzePositions(answers []Answer, questions []content.Question) []PositionStats {
+	bank := make(map[int]content.Question, len(questions))
+	for _, q := range questions {
+		if q.QuestionType() == content.MultipleChoice {
+			bank[q.ID] = q
+		}
+	}
+
+	var positions []PositionStats
+	keyCorrect := make(map[int]int)
+	for _, a := range answers {
+		q, ok := bank[a.QuestionID]
+		if !ok {
+			continue
+		}
+		order := q.Options
+		if a.Options != nil && samePermutation(a.Options, q.Options) {
+			order = a.Options
+		}
+		for len(positions) < len(order) {
+			positions = append(positions, PositionStats{Position: len(positions) + 1})
+		}
+
+		recorded := containsOption(order, a.UserAnswer)
+		for i, option := range order {
+			if recorded {
+				positions[i].Shown++
+				if option == a.UserAnswer {
+					positions[i].Chosen++
+				}
+			}
+			if option == q.Answer {
+				positions[i].KeyShown++
+				if a.Correct {
+					keyCorrect[i]++
+				}
+			}
+		}
+	}
+
+	for i := range positions {
+		if positions[i].Shown > 0 {
+			positions[i].ChosenRate = float64(positions[i].Chosen) / float64(positions[i].Shown)
+		}
+		if positions[i].KeyShown > 0 {
+			positions[i].KeyPValue = float64(keyCorrect[i]) / float64(positions[i].KeyShown)
+		}
+	}
+	return positions
+}
+
+// SortByDiscrimination orders items from least to most discriminating, so the
+// questions most in need of review come first. Items nobody answered go last.
+func SortByDiscrimination(items []ItemStats) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if (items[i].N == 0) != (items[j].N == 0) {
+			return items[j].N == 0
+		}
+		return items[i].PointBiserial < items[j].PointBiserial
+	})
+}
+
+func containsOption(options []string, answer string) bool {
+	for _, option := range options {
+		if option == answer {
+			return true
+		}
+	}
+	return false
+}
+
+// samePermutation reports whether a and b hold the same options in any order
+func samePermutation(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		if counts[v] == 0 {
+			return false
+		}
+		counts[v]--
+	}
+	return true
+}
+
+// pearson returns the correlation of x and y, or 0 if either has no variance
+func pearson(x, y []float64) float64 {
+	n := float64(len(x))
+	if n < 2 {
+		return 0
+	}
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
@@ -0,0 +1,112 @@
+package evaluation
+
+import (
+	"go-adapt/internal/content"
+	"math"
+	"reflect"
+	"testing"
+)
+
+var itemBank = []content.Question{
+	{ID: 1, Options: []string{"a", "b", "c", "d"}, Answer: "a"},
+	{ID: 2, Options: []string{"x", "y"}, Answer: "x"},
+	{ID: 3, Type: content.FreeText, Answer: "hepatitis"},
+	{ID: 4, Options: []string{"m", "n"}, Answer: "m"}, // Nobody answers it
+}
+
+func TestAnalyzeItems(t *testing.T) {
+	answer := func(learner string, question int, choice string, correct bool) Answer {
+		return Answer{LearnerID: learner, QuestionID: question, UserAnswer: choice, Correct: correct}
+	}
+	answers := []Answer{
+		answer("l1", 1, "a", true), answer("l1", 2, "x", true), answer("l1", 3, "hepatitis", true),
+		answer("l2", 1, "b", false), answer("l2", 2, "x", true), answer("l2", 3, "nephritis", false),
+		answer("l3", 1, "a", true), answer("l3", 2, "y", false), answer("l3", 3, "hepatitis", true),
+		answer("l4", 1, "", false), answer("l4", 2, "z", false), answer("l4", 3, "", false),
+	}
+	items := AnalyzeItems(answers, itemBank)
+	if len(items) != len(itemBank) {
+		t.Fatalf("%d items, want one per question", len(items))
+	}
+
+	tests := []struct {
+		name          string
+		item          ItemStats
+		n             int
+		pValue        float64
+		pointBiserial float64
+		counts        []int // Per option, in option order
+		proportions   []float64
+		unused        []string
+		unrecorded    int
+		unmatched     int
+	}{
+		// Rest scores 1, 0.5, 0.5, 0 against item scores 1, 0, 1, 0
+		{"one unrecorded choice", items[0], 4, 0.5, 1 / math.Sqrt(2), []int{2, 1, 0, 0}, []float64{2.0 / 3, 1.0 / 3, 0, 0}, []string{"c", "d"}, 1, 0},
+		// Rest scores 1, 0, 1, 0 against item scores 1, 1, 0, 0
+		{"one unmatched choice", items[1], 4, 0.5, 0, []int{2, 1}, []float64{0.5, 0.25}, []string{}, 0, 1},
+		{"free text keeps no option statistics", items[2], 4, 0.5, 1 / math.Sqrt(2), nil, nil, []string{}, 0, 0},
+		{"unanswered", items[3], 0, 0, 0, []int{0, 0}, []float64{0, 0}, []string{"n"}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			if item.N != tt.n || math.Abs(item.PValue-tt.pValue) > 1e-9 || math.Abs(item.PointBiserial-tt.pointBiserial) > 1e-9 {
+				t.Errorf("n %d, p %v, r_pb %v; want %d, %v, %v", item.N, item.PValue, item.PointBiserial, tt.n, tt.pValue, tt.pointBiserial)
+			}
+			if item.Unrecorded != tt.unrecorded || item.Unmatched != tt.unmatched {
+				t.Errorf("unrecorded %d, unmatched %d; want %d, %d", item.Unrecorded, item.Unmatched, tt.unrecorded, tt.unmatched)
+			}
+			if len(item.Options) != len(tt.counts) {
+				t.Fatalf("%d options, want %d", len(item.Options), len(tt.counts))
+			}
+			for i, option := range item.Options {
+				if option.Count != tt.counts[i] || math.Abs(option.Proportion-tt.proportions[i]) > 1e-9 {
+					t.Errorf("option %s: count %d, proportion %v; want %d, %v", option.Option, option.Count, option.Proportion, tt.counts[i], tt.proportions[i])
+				}
+			}
+			if !reflect.DeepEqual(item.UnusedDistractors, tt.unused) {
+				t.Errorf("unused distractors %v, want %v", item.UnusedDistractors, tt.unused)
+			}
+		})
+	}
+}
+
+func TestAnalyzeItemsExcludesRepeatsFromRestScore(t *testing.T) {
+	// l1 retries question 1 after getting it wrong; neither attempt is part of l1's rest score
+	answers := []Answer{
+		{LearnerID: "l1", QuestionID: 1, UserAnswer: "b"},
+		{LearnerID: "l1", QuestionID: 1, UserAnswer: "a", Correct: true},
+		{LearnerID: "l1", QuestionID: 2, UserAnswer: "x", Correct: true},
+		{LearnerID: "l2", QuestionID: 1, UserAnswer: "c"},
+		{LearnerID: "l2", QuestionID: 2, UserAnswer: "y"},
+	}
+	items := AnalyzeItems(answers, itemBank)
+	// Item scores 0, 1, 0 against rest scores 1, 1, 0
+	if want := 0.5; math.Abs(items[0].PointBiserial-want) > 1e-9 {
+		t.Errorf("r_pb = %v, want %v", items[0].PointBiserial, want)
+	}
+}
+
+func TestAnalyzePositions(t *testing.T) {
+	answers := []Answer{
+		{QuestionID: 1, Options: []string{"b", "a", "c", "d"}, UserAnswer: "a", Correct: true},
+		{QuestionID: 1, UserAnswer: "b"},                                       // No logged order: authored order
+		{QuestionID: 1, Options: []string{"a", "b", "c", "e"}, UserAnswer: ""}, // Not the question's options: authored order, choice unrecorded
+		{QuestionID: 2, Options: []string{"y", "x"}, UserAnswer: "x", Correct: true},
+		{QuestionID: 3, UserAnswer: "hepatitis", Correct: true}, // Not multiple choice
+		{QuestionID: 99, UserAnswer: "a"},                       // Not in the bank
+	}
+	want := []PositionStats{
+		{Position: 1, Shown: 3, Chosen: 0, ChosenRate: 0, KeyShown: 2, KeyPValue: 0},
+		{Position: 2, Shown: 3, Chosen: 3, ChosenRate: 1, KeyShown: 2, KeyPValue: 1},
+		{Position: 3, Shown: 2, Chosen: 0, ChosenRate: 0, KeyShown: 0, KeyPValue: 0},
+		{Position: 4, Shown: 2, Chosen: 0, ChosenRate: 0, KeyShown: 0, KeyPValue: 0},
+	}
+	if got := AnalyzePositions(answers, itemBank); !reflect.DeepEqual(got, want) {
+		t.Errorf("AnalyzePositions =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := AnalyzePositions(nil, itemBank); len(got) != 0 {
+		t.Errorf("no answers gave %d positions", len(got))
+	}
+}
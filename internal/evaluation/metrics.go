@@ -0,0 +1,156 @@
+package evaluation
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Prediction is a model's probability that an answer would be correct, paired with the outcome
+type Prediction struct {
+	P       float64
+	Correct bool
+}
+
+// Scores summarizes predictive accuracy over a set of predictions
+type Scores struct {
+	N           int              `json:"n"`
+	AUC         *float64         `json:"auc"`                   // Nil when AUC is undefined; AUCSkipped says why
+	AUCSkipped  string           `json:"auc_skipped,omitempty"` // Why AUC is nil
+	RMSE        float64          `json:"rmse"`
+	LogLoss     float64          `json:"log_loss"` // Mean negative log-likelihood
+	Calibration []CalibrationBin `json:"calibration"`
+}
+
+// CalibrationBin compares mean predicted probability with the observed correct rate
+type CalibrationBin struct {
+	Lower         float64 `json:"lower"`
+	Upper         float64 `json:"upper"`
+	Count         int     `json:"count"`
+	MeanPredicted float64 `json:"mean_predicted"`
+	ObservedRate  float64 `json:"observed_rate"`
+}
+
+const calibrationBins = 10
+
+func Score(predictions []Prediction) Scores {
+	scores := Scores{
+		N:           len(predictions),
+		RMSE:        RMSE(predictions),
+		LogLoss:     LogLoss(predictions),
+		Calibration: Calibration(predictions, calibrationBins),
+	}
+	if auc, err := AUC(predictions); err != nil {
+		scores.AUCSkipped = err.Error()
+	} else {
+		scores.AUC = &auc
+	}
+	return scores
+}
+
+// ErrOneClass means every outcome was correct, or every outcome incorrect, so
+// there is no pair to rank and AUC is undefined
+var ErrOneClass = errors.New("AUC needs both correct and incorrect answers")
+
+// AUC is the probability a random correct answer was predicted higher than a
+// random incorrect one (Mann-Whitney U), with ties counted as half. It returns
+// ErrOneClass when the outcomes are all the same.
+func AUC(predictions []Prediction) (float64, error) {
+	sorted := make([]Prediction, len(predictions))
+	copy(sorted, predictions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].P < sorted[j].P })
+
+	var positives, negatives, rankSum float64
+	for i := 0; i < len(sorted); {
+		// Average rank over a run of tied predictions
+		j := i
+		for j < len(sorted) && sorted[j].P == sorted[i].P {
+			j++
+		}
+		avgRank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if sorted[k].Correct {
+				positives++
+				rankSum += avgRank
+			} else {
+				negatives++
+			}
+		}
+		i = j
+	}
+	if positives == 0 || negatives == 0 {
+		return 0, ErrOneClass
+	}
+	return (rankSum - positives*(positives+1)/2) / (positives * negatives), nil
+}
+
+func RMSE(predictions []Prediction) float64 {
+	if len(predictions) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, p := range predictions {
+		d := p.P - outcome(p.Correct)
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(len(predictions)))
+}
+
+func LogLoss(predictions []Prediction) float64 {
+	if len(predictions) == 0 {
+		return 0
+	}
+	const eps = 1e-9
+	var sum float64
+	for _, p := range predictions {
+		prob := math.Min(1-eps, math.Max(eps, p.P))
+		if p.Correct {
+			sum -= math.Log(prob)
+		} else {
+			sum -= math.Log(1 - prob)
+		}
+	}
+	return sum / float64(len(predictions))
+}
+
+// Calibration groups predictions into equal-width probability bins; empty bins are omitted
+func Calibration(predictions []Prediction, bins int) []CalibrationBin {
+	counts := make([]int, bins)
+	predicted := make([]float64, bins)
+	observed := make([]float64, bins)
+	for _, p := range predictions {
+		b := int(p.P * float64(bins))
+		if b >= bins {
+			b = bins - 1
+		}
+		if b < 0 {
+			b = 0
+		}
+		counts[b]++
+		predicted[b] += p.P
+		observed[b] += outcome(p.Correct)
+	}
+
+	result := []CalibrationBin{}
+	for b := 0; b < bins; b++ {
+		if counts[b] == 0 {
+			continue
+		}
+		n := float64(counts[b])
+		result = append(result, CalibrationBin{
+			Lower:         float64(b) / float64(bins),
+			Upper:         float64(b+1) / float64(bins),
+			Count:         counts[b],
+			MeanPredicted: predicted[b] / n,
+			ObservedRate:  observed[b] / n,
+		})
+	}
+	return result
+}
+
+func outcome(correct bool) float64 {
+	if correct {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,81 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAUC(t *testing.T) {
+	tests := []struct {
+		name        string
+		predictions []Prediction
+		want        float64
+		err         error
+	}{
+		{"perfect", []Prediction{{0.9, true}, {0.8, true}, {0.2, false}, {0.1, false}}, 1, nil},
+		{"reversed", []Prediction{{0.1, true}, {0.9, false}}, 0, nil},
+		{"ties count half", []Prediction{{0.5, true}, {0.5, false}}, 0.5, nil},
+		{"mixed", []Prediction{{0.9, true}, {0.7, false}, {0.6, true}, {0.2, false}}, 0.75, nil},
+		{"all correct", []Prediction{{0.9, true}, {0.4, true}}, 0, ErrOneClass},
+		{"all incorrect", []Prediction{{0.9, false}, {0.4, false}}, 0, ErrOneClass},
+		{"empty", nil, 0, ErrOneClass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AUC(tt.predictions)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("AUC = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreSkipsUndefinedAUC(t *testing.T) {
+	scores := Score([]Prediction{{0.9, true}, {0.4, true}})
+	if scores.AUC != nil {
+		t.Errorf("AUC = %v for all-correct answers", *scores.AUC)
+	}
+	if scores.AUCSkipped == "" {
+		t.Error("no reason given for skipping AUC")
+	}
+
+	scores = Score([]Prediction{{0.9, true}, {0.4, false}})
+	if scores.AUC == nil || *scores.AUC != 1 || scores.AUCSkipped != "" {
+		t.Errorf("AUC = %v, skipped %q; want 1", scores.AUC, scores.AUCSkipped)
+	}
+}
+
+// A skill every learner always gets right has no AUC, which must not break the JSON report
+func TestEvaluateAllCorrectSkillEncodes(t *testing.T) {
+	seqs := Sequences{
+		"roots":    {"a": {true, true, true}, "b": {true, true}},
+		"suffixes": {"a": {false, true, true}, "b": {false, false, true}},
+	}
+	result := Evaluate(seqs, BKTFactory(0.2, 0.1, 0.1, 0.2))
+
+	if roots := result.PerSkill["roots"]; roots.AUC != nil || roots.AUCSkipped == "" {
+		t.Errorf("all-correct skill: AUC %v, skipped %q", roots.AUC, roots.AUCSkipped)
+	}
+	if result.PerSkill["suffixes"].AUC == nil || result.Overall.AUC == nil {
+		t.Error("AUC missing for answers with both outcomes")
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("encoding the result: %v", err)
+	}
+	var decoded struct {
+		PerSkill map[string]map[string]any `json:"per_skill"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if auc, ok := decoded.PerSkill["roots"]["auc"]; !ok || auc != nil {
+		t.Errorf("all-correct skill encoded auc as %v, want null", auc)
+	}
+}
@@ -0,0 +1,151 @@
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Append-only log of everything that happens in a session, one JSON object
+// per line. Events carry only the fields relevant to their type.
+
+const (
+	SessionStarted   = "session_started"
+	QuestionServed   = "question_served"
+	HintRequested    = "hint_requested"
+	AnswerSubmitted  = "answer_submitted"
+	KnowledgeUpdated = "knowledge_updated"
+	LLMCall          = "llm_call"
+	SessionCompleted = "session_completed"
+)
+
+type Event struct {
+	Type      string    `json:"type"`
+	SessionID string    `json:"session_id"`
+	Time      time.Time `json:"time"`
+
+	// session_started
+	LearnerID   string         `json:"learner_id,omitempty"`
+	Mode        string         `json:"mode,omitempty"`
+	Params      map[string]any `json:"params,omitempty"`
+	BKT         *BKTParams     `json:"bkt,omitempty"`
+	OptionSeed  *int64         `json:"option_seed,omitempty"` // Seed for option order; absent means options were shown as authored
+	Constraints *Constraints   `json:"constraints,omitempty"` // Exposure control and content balancing, if any
+
+	// question_served, hint_requested, answer_submitted
+	QuestionID      int      `json:"question_id,omitempty"`
+	Reasoning       string   `json:"reasoning,omitempty"`
+	Options         []string `json:"options,omitempty"` // question_served: options in the order displayed
+	UserAnswer      string   `json:"user_answer,omitempty"`
+	Correct         bool     `json:"correct,omitempty"`
+	Score           float64  `json:"score,omitempty"`             // Partial credit; absent means 1 if correct, else 0
+	MatchType       string   `json:"match_type,omitempty"`        // How a typed answer matched, see grading.MatchType
+	LatencyMS       int64    `json:"latency_ms,omitempty"`        // answer_submitted: since question_served; llm_call: API round trip
+	ClientLatencyMS int64    `json:"client_latency_ms,omitempty"` // answer_submitted: as measured by the client, if it did
+	Confidence      int      `json:"confidence,omitempty"`        // answer_submitted: the learner's rating, if they gave one
+	HintsUsed       int      `json:"hints_used,omitempty"`        // Hint tiers revealed so far
+	HintHelp        float64  `json:"hint_help,omitempty"`         // answer_submitted: how much the hints gave away, see content.HintHelp
+	HintTiers       int      `json:"hint_tiers,omitempty"`        // hint_requested: how many hints the question has
+
+	// knowledge_updated
+	Knowledge    float64            `json:"knowledge,omitempty"`
+	LearnerModel map[string]float64 `json:"learner_model,omitempty"` // The selector's own estimates, if it keeps any
+
+	// llm_call
+	PromptVersion            string `json:"prompt_version,omitempty"`
+	InputTokens              int64  `json:"input_tokens,omitempty"`
+	OutputTokens             int64  `json:"output_tokens,omitempty"`
+	CacheReadInputTokens     int64  `json:"cache_read_input_tokens,omitempty"`
+	CacheCreationInputTokens int64  `json:"cache_creation_input_tokens,omitempty"`
+
+	// session_completed
+	AnsweredCount int `json:"answered_count,omitempty"`
+}
+
+type BKTParams struct {
+	L0 float64 `json:"l0"`
+	T  float64 `json:"t"`
+	S  float64 `json:"s"`
+	G  float64 `json:"g"`
+}
+
+// Constraints are the exposure control and content balancing options a
+// session's selector was built with, see selection.ConstraintOptions
+type Constraints struct {
+	ExposureTopK    int             `json:"exposure_top_k,omitempty"`
+	ExposureControl map[int]float64 `json:"exposure_control,omitempty"`
+	ContentBalance  map[string]int  `json:"content_balance,omitempty"`
+	SessionLength   int             `json:"session_length,omitempty"` // Questions the content balance quotas are met within
+}
+
+type Logger interface {
+	Log(event Event) error
+}
+
+// JSONLWriter appends events to a JSON Lines file. It is safe for concurrent use.
+type JSONLWriter struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+func OpenJSONL(path string) (*JSONLWriter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+	return &JSONLWriter{
+		file: file,
+		enc:  json.NewEncoder(file),
+	}, nil
+}
+
+func (w *JSONLWriter) Log(event Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enc.Encode(event)
+}
+
+func (w *JSONLWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+// ReadAll reads every event from a JSON Lines log in order
+func ReadAll(r io.Reader) ([]Event, error) {
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, e)
+	}
+	return events, scanner.Err()
+}
+
+// ForSession returns the events of one session, in log order
+func ForSession(events []Event, sessionID string) []Event {
+	var filtered []Event
+	for _, e := range events {
+		if e.SessionID == sessionID {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
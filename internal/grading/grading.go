@@ -0,0 +1,113 @@
+package grading
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Grading of typed answers: tolerant of formatting, configurable synonyms and,
+// optionally, partial credit for near-miss spellings.
+
+// MatchType says how an answer matched what was expected
+type MatchType string
+
+const (
+	Exact      MatchType = "exact"      // Identical to the expected answer
+	Normalized MatchType = "normalized" // Equal once case, whitespace, slashes and hyphens are ignored
+	Synonym    MatchType = "synonym"    // Matches a configured alternate answer or spelling
+	NearMiss   MatchType = "near_miss"  // Within the allowed edit distance; partial credit
+	Rubric     MatchType = "rubric"     // Judged against a rubric rather than matched
+	NoMatch    MatchType = "none"
+)
+
+// rank orders match types from best to worst
+var rank = map[MatchType]int{Exact: 0, Normalized: 1, Synonym: 2, NearMiss: 3, Rubric: 3, NoMatch: 4}
+
+// Worse returns whichever match type is weaker, e.g. to summarize a multi-part answer
+func Worse(a, b MatchType) MatchType {
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// Options configures how leniently one expected answer is matched
+type Options struct {
+	Synonyms []string // Alternate accepted answers, matched after normalization
+	MaxEdits int      // Answers within this many edits score partial credit; 0 turns it off
+}
+
+// Result is the outcome of matching one answer
+type Result struct {
+	Match    MatchType
+	Correct  bool    // Exact, normalized and synonym matches count as correct
+	Score    float64 // 1 when correct, partial for a near miss, otherwise 0
+	Distance int     // Edit distance to the closest accepted answer, after normalization
+}
+
+// Normalize lowercases s, drops slashes and hyphens (so "Dermat/o", "dermato"
+// and "-itis"/"itis" compare equal) and collapses runs of whitespace.
+func Normalize(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '/' || r == '-' || r == '‐' || r == '–' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Match grades answer against expected
+func Match(answer, expected string, opts Options) Result {
+	if answer == expected {
+		return Result{Match: Exact, Correct: true, Score: 1}
+	}
+
+	normalized := Normalize(answer)
+	if normalized == Normalize(expected) {
+		return Result{Match: Normalized, Correct: true, Score: 1}
+	}
+	for _, synonym := range opts.Synonyms {
+		if normalized == Normalize(synonym) {
+			return Result{Match: Synonym, Correct: true, Score: 1}
+		}
+	}
+
+	// Closest accepted answer, for near-miss credit
+	best, bestLen := -1, 0
+	for _, accepted := range append([]string{expected}, opts.Synonyms...) {
+		target := Normalize(accepted)
+		d := Distance(normalized, target)
+		if best < 0 || d < best {
+			best, bestLen = d, len([]rune(target))
+		}
+	}
+	result := Result{Match: NoMatch, Distance: best}
+	if opts.MaxEdits > 0 && best <= opts.MaxEdits && best < bestLen {
+		result.Match = NearMiss
+		result.Score = 1 - float64(best)/float64(bestLen)
+	}
+	return result
+}
+
+// Distance is the Levenshtein edit distance between a and b, counted in runes
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
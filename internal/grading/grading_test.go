@@ -0,0 +1,90 @@
+package grading
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Dermat/o", "dermato"},
+		{"dermato", "dermato"},
+		{"-itis", "itis"},
+		{"–itis", "itis"},
+		{"  Inflammation   of\tthe  LIVER ", "inflammation of the liver"},
+		{"gastro-entero-logy", "gastroenterology"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   string
+		expected string
+		opts     Options
+		match    MatchType
+		correct  bool
+		score    float64
+		distance int
+	}{
+		{"exact", "hepatitis", "hepatitis", Options{}, Exact, true, 1, 0},
+		{"case and slashes", "Hepat/O", "hepato", Options{}, Normalized, true, 1, 0},
+		{"synonym", "haematology", "hematology", Options{Synonyms: []string{"Haematology"}}, Synonym, true, 1, 0},
+		{"near miss", "hepatitus", "hepatitis", Options{MaxEdits: 2}, NearMiss, false, 1 - 1.0/9, 1},
+		{"near miss to a synonym", "haematolgy", "hematology", Options{Synonyms: []string{"haematology"}, MaxEdits: 1}, NearMiss, false, 1 - 1.0/11, 1},
+		{"too many edits", "hepatoma", "hepatitis", Options{MaxEdits: 2}, NoMatch, false, 0, 4},
+		{"near misses off", "hepatitus", "hepatitis", Options{}, NoMatch, false, 0, 1},
+		{"edits as long as the answer", "ab", "it", Options{MaxEdits: 5}, NoMatch, false, 0, 2},
+		{"empty answer", "", "itis", Options{MaxEdits: 1}, NoMatch, false, 0, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Match(tt.answer, tt.expected, tt.opts)
+			if got.Match != tt.match || got.Correct != tt.correct || math.Abs(got.Score-tt.score) > 1e-9 || got.Distance != tt.distance {
+				t.Errorf("Match = %+v, want %s correct=%v score=%v distance=%d", got, tt.match, tt.correct, tt.score, tt.distance)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"naïve", "naive", 1}, // Counted in runes, not bytes
+		{"abc", "abc", 0},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWorse(t *testing.T) {
+	tests := []struct {
+		a, b, want MatchType
+	}{
+		{Exact, Normalized, Normalized},
+		{Synonym, Exact, Synonym},
+		{NearMiss, NoMatch, NoMatch},
+		{Exact, Exact, Exact},
+	}
+	for _, tt := range tests {
+		if got := Worse(tt.a, tt.b); got != tt.want {
+			t.Errorf("Worse(%s, %s) = %s, want %s", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
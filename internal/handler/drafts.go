@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"go-adapt/internal/authoring"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GenerateDraftsRequest struct {
+	Tag        string  `json:"tag"`
+	Difficulty float64 `json:"difficulty"`
+	Count      int     `json:"count"`
+}
+
+type RejectDraftRequest struct {
+	Note string `json:"note"`
+}
+
+// RequireAdminToken admits only requests carrying "Authorization: Bearer <token>".
+// With no token configured every request is refused, so the draft routes stay
+// closed until an admin token is set.
+func RequireAdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(503, gin.H{"error": "draft review is disabled - admin token not configured"})
+			return
+		}
+		given, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(401, gin.H{"error": "admin token required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// GenerateDrafts asks the LLM for new questions and queues the valid ones for review
+func (h *Handler) GenerateDrafts(c *gin.Context) {
+	if h.drafts == nil || h.deps.LLMClient == nil {
+		c.JSON(503, gin.H{"error": "question generation is not available"})
+		return
+	}
+	var req GenerateDraftsRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	if req.Count == 0 {
+		req.Count = 1
+	}
+
+	questions, err := h.drafts.GetAll()
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	taxonomy := authoring.Taxonomy(questions, h.deps.SkillGraph)
+	if !taxonomy[req.Tag] || req.Difficulty < 0 || req.Difficulty > 1 || req.Count < 1 || req.Count > authoring.MaxGenerate {
+		c.JSON(400, gin.H{"error": fmt.Sprintf("tag must be a known tag, difficulty between 0 and 1, and count between 1 and %d", authoring.MaxGenerate)})
+		return
+	}
+
+	result, err := authoring.Generate(c.Request.Context(), h.deps.LLMClient, h.drafts, taxonomy, req.Tag, req.Difficulty, req.Count, time.Now())
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.JSON(504, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(502, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, result)
+}
+
+// GetDrafts lists drafts, optionally filtered by status
+func (h *Handler) GetDrafts(c *gin.Context) {
+	if h.drafts == nil {
+		c.JSON(503, gin.H{"error": "question generation is not available"})
+		return
+	}
+	drafts := h.drafts.Queue().List(authoring.Status(c.Query("status")))
+	if drafts == nil {
+		drafts = []authoring.Draft{}
+	}
+	c.JSON(200, gin.H{"drafts": drafts})
+}
+
+// ApproveDraft adds a pending draft to the bank
+func (h *Handler) ApproveDraft(c *gin.Context) {
+	if h.drafts == nil {
+		c.JSON(503, gin.H{"error": "question generation is not available"})
+		return
+	}
+	draft, err := h.drafts.Approve(c.Param("id"), time.Now())
+	if err != nil {
+		c.JSON(draftErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, draft)
+}
+
+// RejectDraft keeps a pending draft out of the bank
+func (h *Handler) RejectDraft(c *gin.Context) {
+	if h.drafts == nil {
+		c.JSON(503, gin.H{"error": "question generation is not available"})
+		return
+	}
+	var req RejectDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+	draft, err := h.drafts.Queue().Reject(c.Param("id"), req.Note, time.Now())
+	if err != nil {
+		c.JSON(draftErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, draft)
+}
+
+func draftErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, authoring.ErrDraftNotFound):
+		return 404
+	case errors.Is(err, authoring.ErrNotPending):
+		return 409
+	default:
+		return 500
+	}
+}
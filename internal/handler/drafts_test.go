@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"go-adapt/internal/authoring"
+	"go-adapt/internal/content"
+	"go-adapt/internal/review"
+	"go-adapt/internal/selection"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDraftRoutesRequireAdminToken(t *testing.T) {
+	queue, err := authoring.NewQueue("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bank := authoring.NewBank(content.NewStaticBank(), queue)
+	h := NewHandler(bank, nil, review.NewMemoryStore(), selection.NewBanditStore(), nil, nil, bank)
+
+	tests := []struct {
+		name          string
+		configured    string
+		authorization string
+		code          int
+	}{
+		{"no token configured", "", "Bearer ", 503},
+		{"no token configured, any sent", "", "Bearer secret", 503},
+		{"missing", "secret", "", 401},
+		{"wrong", "secret", "Bearer guess", 401},
+		{"not a bearer token", "secret", "secret", 401},
+		{"correct", "secret", "Bearer secret", 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			drafts := r.Group("/drafts", RequireAdminToken(tt.configured))
+			drafts.GET("", h.GetDrafts)
+			drafts.POST("/:id/approve", h.ApproveDraft)
+
+			for _, route := range []struct{ method, path string }{{"GET", "/drafts"}, {"POST", "/drafts/1/approve"}} {
+				req := httptest.NewRequest(route.method, route.path, nil)
+				if tt.authorization != "" {
+					req.Header.Set("Authorization", tt.authorization)
+				}
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+
+				want := tt.code
+				if want == 200 && route.method == "POST" {
+					want = 404 // Authorized, but there is no draft 1
+				}
+				if w.Code != want {
+					t.Errorf("%s %s = %d, want %d", route.method, route.path, w.Code, want)
+				}
+			}
+		})
+	}
+}
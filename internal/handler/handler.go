@@ -1,9 +1,17 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"go-adapt/internal/authoring"
 	"go-adapt/internal/content"
+	"go-adapt/internal/eventlog"
+	"go-adapt/internal/grading"
 	"go-adapt/internal/llm"
+	"go-adapt/internal/review"
+	"go-adapt/internal/selection"
 	"go-adapt/internal/session"
 	"math/rand"
 	"sync"
@@ -16,15 +24,43 @@ type Handler struct {
 	mu sync.RWMutex
 	sessions map[string]*session.SessionManager
 	questionBank content.QuestionBank
-	llmClient *llm.LLMClient
+	reviewStore review.Store // Spaced-repetition schedules, persisted between sessions
+	registry *selection.Registry
+	deps selection.Deps // Shared resources handed to every selector factory
+	events eventlog.Logger // Append-only record of every session interaction
+	rubricGrader content.RubricGrader // Grades explanation questions; nil without an LLM client
+	hints *content.HintSource // Authored hints, or LLM-generated ones when there is a client
+	drafts *authoring.Bank // Generated questions awaiting review; nil disables generation
 }
 
-func NewHandler(qb content.QuestionBank, llmClient *llm.LLMClient) (*Handler){
-	return &Handler{
+// NewHandler serves sessions from qb. When drafts is non-nil it should be qb
+// itself, so approved questions reach new sessions. banditStore is shared by
+// all bandit sessions so item statistics accumulate.
+func NewHandler(qb content.QuestionBank, llmClient *llm.LLMClient, reviewStore review.Store, banditStore *selection.BanditStore, skillGraph *content.SkillGraph, events eventlog.Logger, drafts *authoring.Bank) (*Handler){
+	h := &Handler{
 		sessions: make(map[string]*session.SessionManager),
 		questionBank: qb,
-		llmClient: llmClient,
+		drafts: drafts,
+		reviewStore: reviewStore,
+		events: events,
+		registry: selection.DefaultRegistry(),
+		deps: selection.Deps{
+			QuestionBank: qb,
+			LLMClient:    llmClient,
+			BanditStore:  banditStore,
+			ReviewStore:  reviewStore,
+			SkillGraph:   skillGraph,
+		},
 	}
+	if llmClient != nil {
+		h.rubricGrader = llmClient
+		h.hints = content.NewHintSource(llmClient)
+	} else {
+		h.hints = content.NewHintSource(nil)
+		// Explanations can't be graded without the LLM, so don't select them
+		h.deps.QuestionBank = content.WithoutTypes(qb, content.Explanation)
+	}
+	return h
 }
 
 func (h *Handler) GetSession(sessionID string) (*session.SessionManager, bool) {
@@ -43,29 +79,59 @@ func (h *Handler) CreateSession( sessionID string, mgr *session.SessionManager)
 
   // Add these request/response structs
 type StartSessionRequest struct {
-	Mode string  `json:"mode"` // "bkt" or "llm"
+	Mode string  `json:"mode"` // A registered selection mode, see GET /modes. Defaults to "bkt"
 	L0   float64 `json:"l0,omitempty"`
 	T    float64 `json:"t,omitempty"`
 	S    float64 `json:"s,omitempty"`
 	G    float64 `json:"g,omitempty"`
+	Params map[string]any `json:"params,omitempty"` // Mode-specific parameters, see GET /modes
+	// Shorthands for the matching mode parameters
+	PromptVersion string `json:"prompt_version,omitempty"`
+	LearnerID string `json:"learner_id,omitempty"`
+	MasteryThreshold float64 `json:"mastery_threshold,omitempty"`
+	ExposureTopK   int            `json:"exposure_top_k,omitempty"`  // Pick randomly among the k best questions
+	ContentBalance map[string]int `json:"content_balance,omitempty"` // Minimum questions per tag in the session
+	ExposureControl map[int]float64 `json:"exposure_control,omitempty"` // Sympson-Hetter: question ID -> probability of administering it when selected
+	OptionSeed     *int64         `json:"option_seed,omitempty"`     // Fixes the option order; random if omitted
 }
 
 type StartSessionResponse struct {
-	SessionID string `json:"session_id"`
-	Mode      string `json:"mode"`
+	SessionID     string `json:"session_id"`
+	Mode          string `json:"mode"`
+	PromptVersion string `json:"prompt_version,omitempty"`
+	Params        map[string]any `json:"params,omitempty"` // Resolved mode parameters, including defaults
+	OptionSeed    int64  `json:"option_seed"` // Options are shuffled per question with this seed
 }
 
 type SubmitAnswerRequest struct {
 	SessionID  string `json:"session_id"`
 	QuestionID int    `json:"question_id"`
-	UserAnswer string `json:"user_answer"`
+	UserAnswer json.RawMessage `json:"user_answer"` // A string or an array of strings, per the question's answer schema
+	ClientLatencyMS int64 `json:"client_latency_ms,omitempty"` // Optional: time from display to submit, as measured by the client
+	Confidence int `json:"confidence,omitempty"` // Optional: how sure the learner is, from 1 (guessing) to 4 (certain)
+}
+
+type HintRequest struct {
+	SessionID  string `json:"session_id"`
+	QuestionID int    `json:"question_id"`
+}
+
+type HintResponse struct {
+	Hint           string `json:"hint"`
+	Tier           int    `json:"tier"` // 1 for the first hint
+	Tiers          int    `json:"tiers"`
+	HintsRemaining int    `json:"hints_remaining"`
 }
 
 type SubmitAnswerResponse struct {
 	Correct          bool    `json:"correct"`
+	Score            float64 `json:"score"` // Partial credit from 0 to 1
+	MatchType        grading.MatchType `json:"match_type"` // How the answer matched, e.g. "normalized" or "near_miss"
+	Criteria         []content.CriterionResult `json:"criteria,omitempty"` // Rubric results for explanation questions
 	CorrectAnswer    string  `json:"correct_answer"`
 	Feedback         string  `json:"feedback,omitempty"` // LLM feedback about this answer
-	CurrentKnowledge float64 `json:"current_knowledge,omitempty"`
+	LikelyMisconception bool `json:"likely_misconception,omitempty"` // Wrong but rated confident
+	CurrentKnowledge float64 `json:"current_knowledge,omitempty"` // The selector's P(L) estimate, see selection.LearnerSnapshot; omitted in LLM modes
 	SessionComplete  bool    `json:"session_complete"`
 }
 
@@ -79,9 +145,13 @@ func (h *Handler) StartSession(c *gin.Context) {
 		return
 	}
 
-	// Check if LLM mode is available
-	if req.Mode == "llm" && h.llmClient == nil {
-		c.JSON(400, gin.H{"error": "LLM mode not available - API key not configured"})
+	mode := req.Mode
+	if mode == "" {
+		mode = "bkt"
+	}
+	modeInfo, ok := h.registry.Mode(mode)
+	if !ok {
+		c.JSON(400, gin.H{"error": fmt.Sprintf("Unknown mode %q", mode)})
 		return
 	}
 
@@ -103,17 +173,101 @@ func (h *Handler) StartSession(c *gin.Context) {
 		g = 0.2
 	}
 
+	for id, k := range req.ExposureControl {
+		if k < 0 || k > 1 {
+			c.JSON(400, gin.H{"error": fmt.Sprintf("exposure_control for question %d must be in [0, 1]", id)})
+			return
+		}
+	}
+	var constraints *eventlog.Constraints
+	if req.ExposureTopK > 1 || len(req.ExposureControl) > 0 || len(req.ContentBalance) > 0 {
+		constraints = &eventlog.Constraints{
+			ExposureTopK:    req.ExposureTopK,
+			ExposureControl: req.ExposureControl,
+			ContentBalance:  req.ContentBalance,
+			SessionLength:   MaxQuestionsPerSession,
+		}
+	}
+	opts := selection.ConstraintOptions(constraints)
+	if len(opts) > 0 && modeInfo.Unconstrained {
+		c.JSON(400, gin.H{"error": fmt.Sprintf("mode %q doesn't support exposure_top_k, exposure_control or content_balance", mode)})
+		return
+	}
+
+	params, err := h.registry.ResolveParams(mode, modeParams(req, modeInfo))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	selector, err := h.registry.New(mode, h.deps, selection.Config{
+		L0:        l0,
+		T:         t,
+		S:         s,
+		G:         g,
+		Params:    params,
+		Options:   opts,
+	})
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Options are shuffled so the answer's position gives nothing away; the
+	// seed keeps each question's order stable for the whole session
+	optionSeed := rand.Int63()
+	if req.OptionSeed != nil {
+		optionSeed = *req.OptionSeed
+	}
+
 	sessionID := generateSessionID()
-	manager := session.NewSessionManager(h.questionBank, req.Mode, h.llmClient,
-l0, t, s, g)
+	manager := session.NewSessionManager(h.questionBank, mode, selector, l0, t, s, g)
+	manager.ShuffleOptions(optionSeed)
+	manager.EnableEventLog(sessionID, req.LearnerID, params, constraints, h.events)
 	h.CreateSession(sessionID, manager)
 
+	promptVersion, _ := params["prompt_version"].(string)
 	c.JSON(200, StartSessionResponse{
-		SessionID: sessionID,
-		Mode:      req.Mode,
+		SessionID:     sessionID,
+		Mode:          mode,
+		PromptVersion: promptVersion,
+		Params:        params,
+		OptionSeed:    optionSeed,
 	})
 }
 
+// modeParams merges the top-level shorthand fields into Params for modes that declare them
+func modeParams(req StartSessionRequest, mode selection.Mode) map[string]any {
+	params := make(map[string]any, len(req.Params))
+	for k, v := range req.Params {
+		params[k] = v
+	}
+
+	shorthands := map[string]any{}
+	if req.PromptVersion != "" {
+		shorthands["prompt_version"] = req.PromptVersion
+	}
+	if req.LearnerID != "" {
+		shorthands["learner_id"] = req.LearnerID
+	}
+	if req.MasteryThreshold != 0 {
+		shorthands["mastery_threshold"] = req.MasteryThreshold
+	}
+	for _, p := range mode.Params {
+		if v, ok := shorthands[p.Name]; ok {
+			if _, set := params[p.Name]; !set {
+				params[p.Name] = v
+			}
+		}
+	}
+	return params
+}
+
+// GetModes lists the available selection strategies and their parameters
+func (h *Handler) GetModes(c *gin.Context) {
+	c.JSON(200, gin.H{"modes": h.registry.Modes()})
+}
+
 func (h *Handler) GetNextQuestion(c *gin.Context) {
 	sessionID := c.Query("session_id")
 	if sessionID == "" {
@@ -133,20 +287,83 @@ func (h *Handler) GetNextQuestion(c *gin.Context) {
 		return
 	}
 
+	hintsAvailable := 0
+	if question, err := h.questionBank.GetQuestionByID(result.Question.ID); err == nil {
+		hintsAvailable = h.hints.Available(question)
+	}
+
 	c.JSON(200, gin.H{
-		"question":            result.Question,
+		"question":            result.Question.ForClient(), // Never the bank's Question, which carries the answer
+		"answer_schema":       result.Question.AnswerSchema(),
+		"hints_available":     hintsAvailable,
 		"feedback":            result.Feedback,
 		"selection_reasoning": result.SelectionReasoning,
 		"current_knowledge":   manager.GetCurrentKnowledge(),
 	})
 }
 
+// GetHint reveals the next hint for the question being answered. Hints used
+// are recorded with the answer and make a correct answer count for less.
+func (h *Handler) GetHint(c *gin.Context) {
+	var req HintRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	manager, exists := h.GetSession(req.SessionID)
+	if !exists {
+		c.JSON(404, gin.H{"error": "Session not found"})
+		return
+	}
+	question, err := h.questionBank.GetQuestionByID(req.QuestionID)
+	if err != nil {
+		c.JSON(404, gin.H{"error": "Question not found"})
+		return
+	}
+
+	hints, err := h.hints.Hints(c.Request.Context(), question)
+	if errors.Is(err, content.ErrNoHints) {
+		c.JSON(404, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.JSON(504, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	tier, err := manager.UseHint(req.QuestionID, len(hints))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()}) // Not the question being answered, or out of hints
+		return
+	}
+
+	c.JSON(200, HintResponse{
+		Hint:           hints[tier],
+		Tier:           tier + 1,
+		Tiers:          len(hints),
+		HintsRemaining: len(hints) - tier - 1,
+	})
+}
+
 func (h *Handler) SubmitAnswer(c *gin.Context) {
 	var req SubmitAnswerRequest
 	if err := c.BindJSON(&req); err != nil {
 		c.JSON(400, gin.H{"error": "Invalid request"})
 		return
 	}
+	if req.ClientLatencyMS < 0 {
+		c.JSON(400, gin.H{"error": "client_latency_ms must not be negative"})
+		return
+	}
+	if !content.ValidConfidence(req.Confidence) {
+		c.JSON(400, gin.H{"error": fmt.Sprintf("confidence must be from %d to %d", content.MinConfidence, content.MaxConfidence)})
+		return
+	}
 
 	manager, exists := h.GetSession(req.SessionID)
 	if !exists {
@@ -161,18 +378,50 @@ func (h *Handler) SubmitAnswer(c *gin.Context) {
 		return
 	}
 
-	// Validate answer
-	correct := (req.UserAnswer == question.Answer)
-	result := manager.SubmitAnswer(req.QuestionID, correct)
+	// Grading depends on the question type
+	answer, err := content.ParseAnswer(req.UserAnswer)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	grade, err := question.GradeWith(c.Request.Context(), answer, h.rubricGrader)
+	if errors.Is(err, content.ErrInvalidAnswer) {
+		c.JSON(400, gin.H{"error": err.Error(), "answer_schema": question.AnswerSchema()})
+		return
+	}
+	if errors.Is(err, content.ErrNoRubricGrader) {
+		c.JSON(503, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.JSON(504, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	correct := grade.Correct
+	result := manager.SubmitAnswer(req.QuestionID, content.FormatAnswer(answer), grade, session.AnswerMeta{
+		ClientLatency: time.Duration(req.ClientLatencyMS) * time.Millisecond,
+		Confidence:    req.Confidence,
+	})
 
 	// Check if session is complete
 	answeredCount := len(manager.GetAnsweredIDs())
 	sessionComplete := answeredCount >= MaxQuestionsPerSession
+	if sessionComplete {
+		manager.Complete()
+	}
 
 	response := SubmitAnswerResponse{
 		Correct:          correct,
+		Score:            grade.Score,
+		MatchType:        grade.Match,
+		Criteria:         grade.Criteria,
 		CorrectAnswer:    question.Answer,
 		Feedback:         result.Feedback,
+		LikelyMisconception: result.LikelyMisconception,
 		CurrentKnowledge: result.CurrentKnowledge,
 		SessionComplete:  sessionComplete,
 	}
@@ -197,6 +446,61 @@ func (h *Handler) GetMetrics(c *gin.Context) {
 	c.JSON(200, metrics)
 }
 
+type ReviewItem struct {
+	QuestionID  int       `json:"question_id"`
+	Text        string    `json:"text"`
+	Due         time.Time `json:"due"`
+	IsDue       bool      `json:"is_due"`
+	Stability   float64   `json:"stability_days"`
+	Ease        float64   `json:"ease"`
+	Repetitions int       `json:"repetitions"`
+	Lapses      int       `json:"lapses"`
+}
+
+// GetReviews lists a learner's scheduled reviews, soonest due first
+func (h *Handler) GetReviews(c *gin.Context) {
+	learnerID := c.Query("learner_id")
+	if learnerID == "" {
+		c.JSON(400, gin.H{"error": "learner_id required"})
+		return
+	}
+
+	cards, err := review.Upcoming(h.reviewStore, learnerID)
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	dueCount := 0
+	items := make([]ReviewItem, 0, len(cards))
+	for _, card := range cards {
+		text := ""
+		if question, err := h.questionBank.GetQuestionByID(card.QuestionID); err == nil {
+			text = question.Text
+		}
+		if card.IsDue(now) {
+			dueCount++
+		}
+		items = append(items, ReviewItem{
+			QuestionID:  card.QuestionID,
+			Text:        text,
+			Due:         card.Due,
+			IsDue:       card.IsDue(now),
+			Stability:   card.Stability,
+			Ease:        card.Ease,
+			Repetitions: card.Repetitions,
+			Lapses:      card.Lapses,
+		})
+	}
+
+	c.JSON(200, gin.H{
+		"learner_id": learnerID,
+		"due_count":  dueCount,
+		"reviews":    items,
+	})
+}
+
 func generateSessionID() string {
 	return fmt.Sprintf("%d-%d", time.Now().Unix(), rand.Intn(10000))
 }
\ No newline at end of file
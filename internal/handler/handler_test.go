@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/llm"
+	"go-adapt/internal/llm/llmtest"
+	"go-adapt/internal/review"
+	"go-adapt/internal/selection"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oneQuestionBank serves a single question, so a session is guaranteed to show it
+type oneQuestionBank struct {
+	question content.Question
+}
+
+func (b oneQuestionBank) GetAll() ([]content.Question, error) {
+	return []content.Question{b.question}, nil
+}
+
+func (b oneQuestionBank) GetQuestionByID(id int) (*content.Question, error) {
+	if id != b.question.ID {
+		return nil, fmt.Errorf("question %d not found", id)
+	}
+	q := b.question
+	return &q, nil
+}
+
+func newTestRouter(h *Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/session/start", h.StartSession)
+	r.GET("/session/question", h.GetNextQuestion)
+	r.POST("/session/hint", h.GetHint)
+	r.POST("/session/answer", h.SubmitAnswer)
+	r.GET("/session/metrics", h.GetMetrics)
+	return r
+}
+
+func doJSON(t *testing.T, r http.Handler, method, path string, body any) (int, map[string]any) {
+	t.Helper()
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var decoded map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("%s %s: response is not JSON: %s", method, path, w.Body.String())
+	}
+	return w.Code, decoded
+}
+
+func startSession(t *testing.T, r http.Handler) string {
+	t.Helper()
+	code, resp := doJSON(t, r, "POST", "/session/start", map[string]any{"mode": "bkt"})
+	if code != 200 {
+		t.Fatalf("start session: %d %v", code, resp)
+	}
+	return resp["session_id"].(string)
+}
+
+// Fields learners may see of a question; anything else could give the answer away
+var clientQuestionFields = map[string]bool{
+	"ID": true, "Type": true, "Text": true, "Options": true, "Difficulty": true, "Tags": true,
+}
+
+func TestGetNextQuestionHidesAnswers(t *testing.T) {
+	bank := content.NewStaticBank()
+	prompts, err := llm.LoadDefaultPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := llmtest.NewProvider().Client(prompts) // Explanation questions are only served with a grader
+	for _, id := range []int{1, 21, 22, 23, 24, 25} {
+		question, err := bank.GetQuestionByID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run(fmt.Sprintf("%d_%s", id, question.QuestionType()), func(t *testing.T) {
+			h := NewHandler(oneQuestionBank{*question}, client, review.NewMemoryStore(), selection.NewBanditStore(), nil, nil, nil)
+			r := newTestRouter(h)
+			sessionID := startSession(t, r)
+
+			code, resp := doJSON(t, r, "GET", "/session/question?session_id="+sessionID, nil)
+			if code != 200 {
+				t.Fatalf("get question: %d %v", code, resp)
+			}
+			served, ok := resp["question"].(map[string]any)
+			if !ok {
+				t.Fatalf("no question in %v", resp)
+			}
+			for field := range served {
+				if !clientQuestionFields[field] {
+					t.Errorf("question %d: served field %q", id, field)
+				}
+			}
+			if int(served["ID"].(float64)) != id {
+				t.Errorf("served question %v, want %d", served["ID"], id)
+			}
+		})
+	}
+}
+
+func TestOptionSeedFixesServedOrder(t *testing.T) {
+	question, err := content.NewStaticBank().GetQuestionByID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler(oneQuestionBank{*question}, nil, review.NewMemoryStore(), selection.NewBanditStore(), nil, nil, nil)
+	r := newTestRouter(h)
+
+	served := func(seed int64) []any {
+		code, resp := doJSON(t, r, "POST", "/session/start", map[string]any{"mode": "bkt", "option_seed": seed})
+		if code != 200 {
+			t.Fatalf("start session: %d %v", code, resp)
+		}
+		code, resp = doJSON(t, r, "GET", "/session/question?session_id="+resp["session_id"].(string), nil)
+		if code != 200 {
+			t.Fatalf("get question: %d %v", code, resp)
+		}
+		return resp["question"].(map[string]any)["Options"].([]any)
+	}
+	first, again := served(11), served(11)
+	if fmt.Sprint(first) != fmt.Sprint(again) {
+		t.Errorf("option_seed 11 served %v, then %v", first, again)
+	}
+	want := question.WithShuffledOptions(11).Options
+	if fmt.Sprint(first) != fmt.Sprint(want) {
+		t.Errorf("served %v, want the seed's order %v", first, want)
+	}
+}
+
+func TestNoLLMClientOffersOnlyAuthoredHints(t *testing.T) {
+	bank := content.NewStaticBank()
+	for _, tc := range []struct {
+		id   int
+		want int
+	}{
+		{2, 0},  // No authored hints, and none can be generated
+		{21, 3}, // Three authored hints
+	} {
+		question, err := bank.GetQuestionByID(tc.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h := NewHandler(oneQuestionBank{*question}, nil, review.NewMemoryStore(), selection.NewBanditStore(), nil, nil, nil)
+		r := newTestRouter(h)
+		sessionID := startSession(t, r)
+
+		_, resp := doJSON(t, r, "GET", "/session/question?session_id="+sessionID, nil)
+		if got := int(resp["hints_available"].(float64)); got != tc.want {
+			t.Errorf("question %d: hints_available = %d, want %d", tc.id, got, tc.want)
+		}
+		code, resp := doJSON(t, r, "POST", "/session/hint", map[string]any{"session_id": sessionID, "question_id": tc.id})
+		if tc.want == 0 && code != 404 {
+			t.Errorf("question %d: hint request got %d %v, want 404", tc.id, code, resp)
+		}
+		if tc.want > 0 && code != 200 {
+			t.Errorf("question %d: hint request got %d %v, want 200", tc.id, code, resp)
+		}
+	}
+}
+
+func TestNoLLMClientSelectsNoExplanations(t *testing.T) {
+	h := NewHandler(content.NewStaticBank(), nil, review.NewMemoryStore(), selection.NewBanditStore(), nil, nil, nil)
+	questions, err := h.deps.QuestionBank.GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range questions {
+		if q.QuestionType() == content.Explanation {
+			t.Errorf("question %d is an explanation, which can't be graded without the LLM", q.ID)
+		}
+	}
+	if _, err := h.deps.QuestionBank.GetQuestionByID(25); err != nil {
+		t.Errorf("explanation question no longer found by ID: %v", err)
+	}
+}
+
+func submitExplanation(t *testing.T, client *llm.LLMClient, answer string) (int, map[string]any) {
+	t.Helper()
+	question, err := content.NewStaticBank().GetQuestionByID(25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler(oneQuestionBank{*question}, client, review.NewMemoryStore(), selection.NewBanditStore(), nil, nil, nil)
+	r := newTestRouter(h)
+	sessionID := startSession(t, r)
+	return doJSON(t, r, "POST", "/session/answer", map[string]any{
+		"session_id": sessionID, "question_id": 25, "user_answer": answer,
+	})
+}
+
+func TestExplanationWithoutGraderIsUnavailable(t *testing.T) {
+	code, resp := submitExplanation(t, nil, "hepat/o means liver and -megaly means enlargement")
+	if code != 503 {
+		t.Errorf("got %d %v, want 503", code, resp)
+	}
+}
+
+func TestExplanationGradedAgainstRubric(t *testing.T) {
+	prompts, err := llm.LoadDefaultPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := llmtest.NewProvider(llmtest.ToolUse("record_grade", map[string]any{
+		"criteria": []map[string]any{
+			{"id": "root", "points": 1, "comment": ""},
+			{"id": "suffix", "points": 1, "comment": ""},
+		},
+		"feedback": "Say how the parts combine.",
+	})).Client(prompts)
+
+	code, resp := submitExplanation(t, client, "hepat/o means liver and -megaly means enlargement")
+	if code != 200 {
+		t.Fatalf("got %d %v", code, resp)
+	}
+	if resp["correct"] != false || resp["match_type"] != "rubric" {
+		t.Errorf("graded %v", resp)
+	}
+	if score := resp["score"].(float64); score < 0.66 || score > 0.67 {
+		t.Errorf("score = %v, want 2/3", score)
+	}
+}
+
+func TestSelectionConstraintsRejectedForLLMModes(t *testing.T) {
+	prompts, err := llm.LoadDefaultPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler(content.NewStaticBank(), llmtest.NewProvider().Client(prompts), review.NewMemoryStore(), selection.NewBanditStore(), nil, nil, nil)
+	r := newTestRouter(h)
+
+	constraints := []map[string]any{
+		{"exposure_top_k": 3},
+		{"content_balance": map[string]int{"hepatology": 1}},
+		{"exposure_control": map[string]float64{"1": 0.5}},
+	}
+	for _, mode := range []string{"llm", "hybrid", "irt"} {
+		for _, constraint := range constraints {
+			req := map[string]any{"mode": mode}
+			for k, v := range constraint {
+				req[k] = v
+			}
+			code, resp := doJSON(t, r, "POST", "/session/start", req)
+			want := 200
+			if mode != "irt" {
+				want = 400
+			}
+			if code != want {
+				t.Errorf("%s with %v: got %d %v, want %d", mode, constraint, code, resp, want)
+			}
+		}
+	}
+}
+
+func TestExposureControlValidated(t *testing.T) {
+	r := newTestRouter(NewHandler(content.NewStaticBank(), nil, review.NewMemoryStore(), selection.NewBanditStore(), nil, nil, nil))
+	for k, want := range map[float64]int{-0.1: 400, 0: 200, 0.5: 200, 1: 200, 1.5: 400} {
+		code, resp := doJSON(t, r, "POST", "/session/start", map[string]any{"mode": "bkt", "exposure_control": map[string]float64{"1": k}})
+		if code != want {
+			t.Errorf("exposure_control 1: %v got %d %v, want %d", k, code, resp, want)
+		}
+	}
+}
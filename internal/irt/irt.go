@@ -0,0 +1,132 @@
+package irt
+
+import "math"
+
+// Three-parameter logistic (3PL) item response model used for
+// computerized adaptive testing.
+
+// Item holds calibrated 3PL parameters: discrimination A, difficulty B and guessing C.
+type Item struct {
+	A float64
+	B float64
+	C float64
+}
+
+// Response is a single scored answer to a calibrated item. Score is 1 for
+// correct and 0 for incorrect; values in between are partial credit, treated
+// as fractional evidence of a correct response.
+type Response struct {
+	Item  Item
+	Score float64
+}
+
+// Quadrature grid and standard normal prior used for EAP estimation
+const (
+	thetaMin   = -4.0
+	thetaMax   = 4.0
+	gridPoints = 81
+)
+
+// Probability returns P(correct | theta) under the 3PL model
+func Probability(theta float64, item Item) float64 {
+	return item.C + (1-item.C)/(1+math.Exp(-item.A*(theta-item.B)))
+}
+
+// Information returns the Fisher information the item provides at theta.
+// Selecting the item with the most information minimizes the standard error
+// of the next ability estimate.
+func Information(theta float64, item Item) float64 {
+	p := Probability(theta, item)
+	q := 1 - p
+	if p <= 0 || q <= 0 {
+		return 0
+	}
+	ratio := (p - item.C) / (1 - item.C)
+	return item.A * item.A * (q / p) * ratio * ratio
+}
+
+// EstimateEAP returns the expected a posteriori ability and its posterior
+// standard deviation under a standard normal prior. Unlike MLE it is defined
+// for all-correct and all-incorrect patterns, so it works from the first answer.
+func EstimateEAP(responses []Response) (theta, se float64) {
+	step := (thetaMax - thetaMin) / float64(gridPoints-1)
+
+	var sumW, sumWT float64
+	weights := make([]float64, gridPoints)
+	for i := range weights {
+		t := thetaMin + float64(i)*step
+		w := math.Exp(-t * t / 2)
+		for _, r := range responses {
+			p := Probability(t, r.Item)
+			w *= math.Pow(p, r.Score) * math.Pow(1-p, 1-r.Score)
+		}
+		weights[i] = w
+		sumW += w
+		sumWT += w * t
+	}
+	if sumW == 0 {
+		return 0, 1
+	}
+
+	theta = sumWT / sumW
+	var variance float64
+	for i, w := range weights {
+		d := thetaMin + float64(i)*step - theta
+		variance += w * d * d
+	}
+	return theta, math.Sqrt(variance / sumW)
+}
+
+// EstimateMLE returns the maximum likelihood ability via Newton-Raphson, with
+// its standard error from the test information. It falls back to EAP when the
+// likelihood has no interior maximum (empty, all-correct or all-incorrect patterns).
+func EstimateMLE(responses []Response) (theta, se float64) {
+	if !hasMixedResponses(responses) {
+		return EstimateEAP(responses)
+	}
+
+	theta, _ = EstimateEAP(responses)
+	for iter := 0; iter < 50; iter++ {
+		var gradient, info float64
+		for _, r := range responses {
+			p := Probability(theta, r.Item)
+			ratio := (p - r.Item.C) / (p * (1 - r.Item.C))
+			gradient += r.Item.A * ratio * (r.Score - p)
+			info += Information(theta, r.Item)
+		}
+		if info == 0 {
+			break
+		}
+		delta := gradient / info
+		theta = math.Max(thetaMin, math.Min(thetaMax, theta+delta))
+		if math.Abs(delta) < 1e-6 {
+			break
+		}
+	}
+	return theta, StandardError(theta, responses)
+}
+
+// StandardError returns 1/sqrt(test information) at theta
+func StandardError(theta float64, responses []Response) float64 {
+	var info float64
+	for _, r := range responses {
+		info += Information(theta, r.Item)
+	}
+	if info == 0 {
+		return math.Inf(1)
+	}
+	return 1 / math.Sqrt(info)
+}
+
+func hasMixedResponses(responses []Response) bool {
+	var correct, incorrect bool
+	for _, r := range responses {
+		if r.Score > 0 {
+			correct = true
+		}
+		if r.Score < 1 {
+			incorrect = true
+		}
+	}
+	return correct && incorrect
+}
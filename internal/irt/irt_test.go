@@ -0,0 +1,152 @@
+package irt
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestProbability(t *testing.T) {
+	tests := []struct {
+		name  string
+		theta float64
+		item  Item
+		want  float64
+	}{
+		{"at difficulty, no guessing", 0, Item{A: 1, B: 0}, 0.5},
+		{"at difficulty, with guessing", 1, Item{A: 1.5, B: 1, C: 0.2}, 0.6},
+		{"one logit above", 1, Item{A: 1, B: 0}, 1 / (1 + math.Exp(-1))},
+		{"discrimination scales the logit", 1, Item{A: 2, B: 0}, 1 / (1 + math.Exp(-2))},
+		{"far below is the guessing floor", -40, Item{A: 1, B: 0, C: 0.25}, 0.25},
+		{"far above is certain", 40, Item{A: 1, B: 0, C: 0.25}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Probability(tt.theta, tt.item); !near(got, tt.want, 1e-12) {
+				t.Errorf("Probability = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInformation(t *testing.T) {
+	tests := []struct {
+		name  string
+		theta float64
+		item  Item
+		want  float64
+	}{
+		{"2PL peak is a²/4", 0, Item{A: 1, B: 0}, 0.25},
+		{"2PL peak, steeper item", 0.5, Item{A: 2, B: 0.5}, 1},
+		// p = 0.6, q = 0.4, (p-c)/(1-c) = 0.5: a² q/p 0.25
+		{"3PL at difficulty", 1, Item{A: 1.5, B: 1, C: 0.2}, 2.25 * (0.4 / 0.6) * 0.25},
+		{"far from the item", 40, Item{A: 1, B: 0}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Information(tt.theta, tt.item); !near(got, tt.want, 1e-12) {
+				t.Errorf("Information = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateEAP(t *testing.T) {
+	item := Item{A: 1, B: 0}
+	tests := []struct {
+		name      string
+		responses []Response
+		theta     float64
+		se        float64 // Expected standard error, 0 to skip
+		tolerance float64
+	}{
+		{"no responses is the prior", nil, 0, 1, 0.01},
+		{"half credit on a centered item", []Response{{item, 0.5}}, 0, 0, 1e-9},
+		{"symmetric pattern", []Response{{item, 1}, {item, 0}}, 0, 0, 1e-9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theta, se := EstimateEAP(tt.responses)
+			if !near(theta, tt.theta, tt.tolerance) {
+				t.Errorf("theta = %v, want %v", theta, tt.theta)
+			}
+			if tt.se > 0 && !near(se, tt.se, tt.tolerance) {
+				t.Errorf("se = %v, want %v", se, tt.se)
+			}
+		})
+	}
+}
+
+func TestEstimateEAPOrdersScores(t *testing.T) {
+	item := Item{A: 1.2, B: 0.3, C: 0.2}
+	var previous float64
+	for i, score := range []float64{0, 0.25, 0.5, 0.75, 1} {
+		theta, se := EstimateEAP([]Response{{item, score}, {item, score}})
+		if i > 0 && theta <= previous {
+			t.Errorf("score %v gave theta %v, not above %v for less credit", score, theta, previous)
+		}
+		if se <= 0 || se >= 1 {
+			t.Errorf("score %v: se %v, want below the prior's 1", score, se)
+		}
+		previous = theta
+	}
+
+	right, _ := EstimateEAP([]Response{{Item{A: 1, B: 0}, 1}})
+	wrong, _ := EstimateEAP([]Response{{Item{A: 1, B: 0}, 0}})
+	if right <= 0 || !near(right, -wrong, 1e-9) {
+		t.Errorf("one right gives %v and one wrong %v, want mirror images above and below 0", right, wrong)
+	}
+}
+
+func TestEstimateMLE(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []Response
+		theta     float64
+		se        float64
+	}{
+		// p(θ) = 0.5 exactly at the item's difficulty
+		{"half credit on one item", []Response{{Item{A: 1, B: 1}, 0.5}}, 1, 2},
+		{"one right and one wrong on the same item", []Response{{Item{A: 2, B: -0.5}, 1}, {Item{A: 2, B: -0.5}, 0}}, -0.5, 1 / math.Sqrt(2)},
+		{"quarter credit", []Response{{Item{A: 1, B: 0}, 0.25}}, math.Log(1.0 / 3), 1 / math.Sqrt(0.25*0.75)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theta, se := EstimateMLE(tt.responses)
+			if !near(theta, tt.theta, 1e-6) {
+				t.Errorf("theta = %v, want %v", theta, tt.theta)
+			}
+			if !near(se, tt.se, 1e-6) {
+				t.Errorf("se = %v, want %v", se, tt.se)
+			}
+		})
+	}
+}
+
+func TestEstimateMLEFallsBackToEAP(t *testing.T) {
+	item := Item{A: 1, B: 0, C: 0.2}
+	for name, responses := range map[string][]Response{
+		"empty":         nil,
+		"all correct":   {{item, 1}, {item, 1}},
+		"all incorrect": {{item, 0}, {item, 0}},
+	} {
+		theta, se := EstimateMLE(responses)
+		eapTheta, eapSE := EstimateEAP(responses)
+		if theta != eapTheta || se != eapSE {
+			t.Errorf("%s: MLE %v ± %v, want the EAP %v ± %v", name, theta, se, eapTheta, eapSE)
+		}
+	}
+}
+
+func TestStandardError(t *testing.T) {
+	if se := StandardError(0, nil); !math.IsInf(se, 1) {
+		t.Errorf("no responses: se = %v, want +Inf", se)
+	}
+	responses := []Response{{Item{A: 1, B: 0}, 1}, {Item{A: 1, B: 0}, 0}, {Item{A: 1, B: 0}, 1}, {Item{A: 1, B: 0}, 0}}
+	if se := StandardError(0, responses); !near(se, 1, 1e-12) { // 4 × 0.25 information
+		t.Errorf("se = %v, want 1", se)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"go-adapt/internal/content"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/anthropics/anthropic-sdk-go" // imported as anthropic
 	"github.com/anthropics/anthropic-sdk-go/option"
@@ -15,15 +17,27 @@ import (
 
 type LLMClient struct {
 	*anthropic.Client
-	systemPrompt string
+	prompts *PromptSet
 }
 
-func NewLLMClient(a string) *LLMClient {
-	client := anthropic.NewClient(option.WithAPIKey(a))
-      return &LLMClient{
-          Client: &client,
-		  systemPrompt: LLMGuidedPrompt,
-      }
+// NewLLMClient calls the Anthropic API with key a. Extra options are passed to
+// the SDK, e.g. option.WithHTTPClient to answer from a fake provider in tests.
+func NewLLMClient(a string, prompts *PromptSet, opts ...option.RequestOption) *LLMClient {
+	client := anthropic.NewClient(append([]option.RequestOption{option.WithAPIKey(a)}, opts...)...)
+	return &LLMClient{
+		Client:  &client,
+		prompts: prompts,
+	}
+}
+
+// HasPromptVersion reports whether a session may request the given prompt version.
+func (client *LLMClient) HasPromptVersion(version string) bool {
+	return client.prompts.Has(version)
+}
+
+// PromptVersions lists the prompt versions loaded at startup.
+func (client *LLMClient) PromptVersions() []string {
+	return client.prompts.Versions()
 }
 
 /*   Methods (for now):
@@ -46,31 +60,64 @@ type LLMResponse struct {
 	Feedback           string
 	SelectionReasoning string
 	UserModel          *UserModel
+	Usage              *Usage
+	PromptVersion      string
 }
 
-func (client *LLMClient) SelectNextQuestion(questionBank []content.Question, answeredHistory []content.AnswerRecord) (*LLMResponse, error){
-	questions, _ := toJSONString(questionBank)
-	history, _ := toJSONString(answeredHistory)
+// Usage records token counts and wall-clock latency of a single LLM call.
+// CacheReadInputTokens > 0 means the system prompt and question bank prefix was served from the prompt cache.
+type Usage struct {
+	InputTokens              int64
+	OutputTokens             int64
+	CacheCreationInputTokens int64
+	CacheReadInputTokens     int64
+	Latency                  time.Duration
+}
 
-	inputPrompt := fmt.Sprintf(
-		`
-		<question_bank>
-		%s
-		</question_bank>
+func (client *LLMClient) SelectNextQuestion(promptVersion string, questionBank []content.Question, answeredHistory []content.AnswerRecord) (*LLMResponse, error){
+	return client.selectNext(promptVersion, questionBank, answeredHistory, "Select the next question ID.")
+}
+
+// Candidate is a question pre-selected by a psychometric model, with the score it was ranked by
+type Candidate struct {
+	QuestionID int
+	Score      float64
+}
+
+// SelectFromCandidates asks the LLM to choose the next question from a short,
+// pre-ranked candidate list instead of the whole bank. The bank is still sent
+// (and cached) so the LLM can read the candidates' content.
+func (client *LLMClient) SelectFromCandidates(promptVersion string, questionBank []content.Question, answeredHistory []content.AnswerRecord, candidates []Candidate, scoreName string) (*LLMResponse, error){
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<candidates>\nquestion_id|%s", scoreName)
+	for _, c := range candidates {
+		fmt.Fprintf(&sb, "\n%d|%.3f", c.QuestionID, c.Score)
+	}
+	sb.WriteString("\n</candidates>\n\n")
+	sb.WriteString("A psychometric model has ranked these candidates, best first. " +
+		"Select the next question ID from the candidates only, and use <selection_reasoning> to justify your choice against the other candidates.")
+
+	return client.selectNext(promptVersion, questionBank, answeredHistory, sb.String())
+}
 
-		<answer_history>
-		%s
-		</answer_history>
+func (client *LLMClient) selectNext(promptVersion string, questionBank []content.Question, answeredHistory []content.AnswerRecord, instruction string) (*LLMResponse, error){
+	system, err := client.buildSystemBlocks(promptVersion, questionBank)
+	if err != nil {
+		return nil, err
+	}
 
-		Select the next question ID.
-		`, questions, history)
+	inputPrompt := fmt.Sprintf(
+		`<answer_history>
+%s
+</answer_history>
+
+%s`, encodeHistory(questionBank, answeredHistory), instruction)
 
+	start := time.Now()
 	message, err := client.Messages.New(context.TODO(), anthropic.MessageNewParams{
 		Model: anthropic.ModelClaudeHaiku4_5_20251001,
 		MaxTokens: 4096,
-		System: []anthropic.TextBlockParam{
-			{Text: client.systemPrompt},
-		},
+		System: system,
 		Messages: []anthropic.MessageParam{
 			anthropic.NewUserMessage(anthropic.NewTextBlock(inputPrompt)),
 		},
@@ -78,7 +125,13 @@ func (client *LLMClient) SelectNextQuestion(questionBank []content.Question, ans
 	if err != nil {
 		return nil, fmt.Errorf("failed to call LLM API: %w", err)
 	}
-	fmt.Printf("%+v\n", message.Content)
+	usage := &Usage{
+		InputTokens:              message.Usage.InputTokens,
+		OutputTokens:             message.Usage.OutputTokens,
+		CacheCreationInputTokens: message.Usage.CacheCreationInputTokens,
+		CacheReadInputTokens:     message.Usage.CacheReadInputTokens,
+		Latency:                  time.Since(start),
+	}
 
 	//extract ID and feedback
 	responseText := message.Content[0].Text
@@ -97,9 +150,100 @@ func (client *LLMClient) SelectNextQuestion(questionBank []content.Question, ans
 		Feedback:           feedback,
 		SelectionReasoning: reasoning,
 		UserModel:          userModel,
+		Usage:              usage,
+		PromptVersion:      promptVersion,
 	}, nil
 }
 
+// buildSystemBlocks returns the system prompt followed by the question bank.
+// Both are identical for every call in a session, so a cache breakpoint on the
+// bank block lets the API reuse the whole prefix; only the history is re-sent.
+// Haiku 4.5 only caches prefixes of 4096 tokens or more. The static bank's
+// prefix is about 3k tokens, so caching has no effect with it alone and the
+// saving comes from the compact encoding; it takes effect once templated
+// questions or approved drafts grow the bank past that.
+func (client *LLMClient) buildSystemBlocks(promptVersion string, questionBank []content.Question) ([]anthropic.TextBlockParam, error) {
+	systemPrompt, err := client.prompts.Get(promptVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	bankBytes, err := json.Marshal(promptBank(questionBank))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode question bank: %w", err)
+	}
+
+	return []anthropic.TextBlockParam{
+		{Text: systemPrompt},
+		{
+			Text:         "<question_bank>\n" + string(bankBytes) + "\n</question_bank>",
+			CacheControl: anthropic.NewCacheControlEphemeralParam(),
+		},
+	}, nil
+}
+
+// promptQuestion is what the selector is told about each question: the fields
+// the system prompt describes plus the options. Grading and hint material
+// (synonyms, rubrics, hints, per-option feedback) stays out of the cached prefix.
+type promptQuestion struct {
+	ID         int
+	Type       content.QuestionType `json:",omitempty"`
+	Text       string
+	Options    []string `json:",omitempty"`
+	Answer     string
+	Difficulty float64
+	Tags       []string
+}
+
+func promptBank(questionBank []content.Question) []promptQuestion {
+	view := make([]promptQuestion, 0, len(questionBank))
+	for _, q := range questionBank {
+		view = append(view, promptQuestion{
+			ID:         q.ID,
+			Type:       q.Type,
+			Text:       q.Text,
+			Options:    q.Options,
+			Answer:     q.Answer,
+			Difficulty: q.Metadata.Difficulty,
+			Tags:       q.Metadata.Tags,
+		})
+	}
+	return view
+}
+
+// encodeHistory renders the answer history as one line per answer:
+// question_id|correct|score|chosen|hints|confidence|difficulty|tags. Question text, options and feedback
+// are already in the cached bank, so repeating them here only costs tokens; the
+// chosen option is kept so feedback can address the specific misconception.
+func encodeHistory(questionBank []content.Question, answeredHistory []content.AnswerRecord) string {
+	byID := make(map[int]*content.Question, len(questionBank))
+	for i := range questionBank {
+		byID[questionBank[i].ID] = &questionBank[i]
+	}
+
+	var sb strings.Builder
+	sb.WriteString("question_id|correct|score|chosen|hints|confidence|difficulty|tags")
+	for _, record := range answeredHistory {
+		correct := 0
+		if record.Correct {
+			correct = 1
+		}
+		difficulty := 0.0
+		tags := ""
+		if q, ok := byID[record.QuestionID]; ok {
+			difficulty = q.Metadata.Difficulty
+			tags = strings.Join(q.Metadata.Tags, ";")
+		}
+		fmt.Fprintf(&sb, "\n%d|%d|%.2f|%s|%d|%d|%.2f|%s", record.QuestionID, correct, record.Score, historyField(record.UserAnswer), record.HintsUsed, record.Confidence, difficulty, tags)
+	}
+	return sb.String()
+}
+
+// historyField keeps free text from breaking the one-line, pipe-separated history format
+func historyField(s string) string {
+	return strings.NewReplacer("|", "/", "\n", " ", "\r", " ").Replace(strings.TrimSpace(s))
+}
+
 func parseQuestionID(response string) (int,error){
 	re := regexp.MustCompile(`<next_question_id>\s*(\d+)\s*</next_question_id>`)
 	matches := re.FindStringSubmatch(response)
@@ -155,14 +299,3 @@ func parseUserModel(response string) *UserModel {
 		DifficultyTolerance: extractFloat("difficulty_tolerance"),
 	}
 }
-
-func toJSONString(data any) (string, error) {
-	jsonBytes, err := json.MarshalIndent(
-		data, "", "  ",
-	)
-	if err != nil {
-		return "", err
-	}
-	return string(jsonBytes), nil
-}
-
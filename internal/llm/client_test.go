@@ -0,0 +1,218 @@
+package llm_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/llm"
+	"go-adapt/internal/llm/llmtest"
+	"strings"
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+)
+
+const selectionReply = `<analysis>Steady progress.</analysis>
+<user_model><knowledge_level>0.5</knowledge_level></user_model>
+<feedback>Good work.</feedback>
+<next_question_id>2</next_question_id>
+<selection_reasoning>Next in difficulty.</selection_reasoning>`
+
+func testPrompts(tb testing.TB) *llm.PromptSet {
+	tb.Helper()
+	prompts, err := llm.LoadDefaultPrompts()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return prompts
+}
+
+func staticQuestions(tb testing.TB) []content.Question {
+	tb.Helper()
+	questions, err := content.NewStaticBank().GetAll()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return questions
+}
+
+// templatedQuestions is the static bank plus the templated bank, as served
+// with TEMPLATE_BANK_SEED set: enough questions for the prefix to be cached
+func templatedQuestions(tb testing.TB) []content.Question {
+	tb.Helper()
+	templates, err := content.NewTemplateBank(content.MedicalLexicon(), 1, content.TemplateIDBase, 1)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	questions, err := content.NewMultiBank(content.NewStaticBank(), templates).GetAll()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return questions
+}
+
+// sessionHistories returns the history after each answer of a session that
+// answers the bank in order, getting every third question wrong
+func sessionHistories(questions []content.Question, answers int) [][]content.AnswerRecord {
+	var histories [][]content.AnswerRecord
+	var history []content.AnswerRecord
+	for i := 0; i < answers && i < len(questions); i++ {
+		record := content.AnswerRecord{QuestionID: questions[i].ID, Correct: i%3 != 2, UserAnswer: questions[i].Answer}
+		if record.Correct {
+			record.Score = 1
+		}
+		history = append(history, record)
+		histories = append(histories, append([]content.AnswerRecord(nil), history...))
+	}
+	return histories
+}
+
+// legacySelectNext sends the request shape used before prompt caching: the
+// indented bank and full history in the user message, nothing cacheable
+func legacySelectNext(client *llm.LLMClient, system string, questions []content.Question, history []content.AnswerRecord) (anthropic.Usage, error) {
+	bank, _ := json.MarshalIndent(questions, "", "  ")
+	answers, _ := json.MarshalIndent(history, "", "  ")
+	message, err := client.Messages.New(context.Background(), anthropic.MessageNewParams{
+		Model:     anthropic.ModelClaudeHaiku4_5_20251001,
+		MaxTokens: 4096,
+		System:    []anthropic.TextBlockParam{{Text: system}},
+		Messages: []anthropic.MessageParam{
+			anthropic.NewUserMessage(anthropic.NewTextBlock(fmt.Sprintf(
+				"<question_bank>\n%s\n</question_bank>\n\n<answer_history>\n%s\n</answer_history>\n\nSelect the next question ID.",
+				bank, answers))),
+		},
+	})
+	if err != nil {
+		return anthropic.Usage{}, err
+	}
+	return message.Usage, nil
+}
+
+type tokenTotals struct {
+	input, cacheRead, cacheWrite int64
+}
+
+func runSession(tb testing.TB, shape string, questions []content.Question, calls int) tokenTotals {
+	tb.Helper()
+	prompts := testPrompts(tb)
+	client := llmtest.NewProvider(llmtest.Text(selectionReply)).Client(prompts)
+	system, err := prompts.Get(llm.DefaultPromptVersion)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	var totals tokenTotals
+	for _, history := range sessionHistories(questions, calls) {
+		switch shape {
+		case "legacy":
+			usage, err := legacySelectNext(client, system, questions, history)
+			if err != nil {
+				tb.Fatal(err)
+			}
+			totals.input += usage.InputTokens
+			totals.cacheRead += usage.CacheReadInputTokens
+			totals.cacheWrite += usage.CacheCreationInputTokens
+		case "cached":
+			resp, err := client.SelectNextQuestion(llm.DefaultPromptVersion, questions, history)
+			if err != nil {
+				tb.Fatal(err)
+			}
+			totals.input += resp.Usage.InputTokens
+			totals.cacheRead += resp.Usage.CacheReadInputTokens
+			totals.cacheWrite += resp.Usage.CacheCreationInputTokens
+		}
+	}
+	return totals
+}
+
+// Haiku 4.5 won't cache the static bank's prefix, which is too short, so
+// with it alone the saving is the compact encoding; a templated bank is long
+// enough to cache and re-sends only the history.
+func TestCachedRequestShapeSendsLessUncachedInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		questions []content.Question
+		cached    bool
+		fewer     int64 // Legacy uncached input over cached, at least
+	}{
+		{"static bank", staticQuestions(t), false, 2},
+		{"templated bank", templatedQuestions(t), true, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legacy := runSession(t, "legacy", tt.questions, 10)
+			cached := runSession(t, "cached", tt.questions, 10)
+			if legacy.cacheRead != 0 || legacy.cacheWrite != 0 {
+				t.Errorf("legacy requests used the cache: %+v", legacy)
+			}
+			if used := cached.cacheWrite > 0 && cached.cacheRead > 0; used != tt.cached {
+				t.Errorf("cached requests wrote %d and read %d cached tokens, want caching %v", cached.cacheWrite, cached.cacheRead, tt.cached)
+			}
+			if !tt.cached && (cached.cacheWrite != 0 || cached.cacheRead != 0) {
+				t.Errorf("prefix below the minimum was cached: %+v", cached)
+			}
+			if cached.input*tt.fewer > legacy.input {
+				t.Errorf("cached shape sent %d uncached input tokens, legacy %d; want at least %dx fewer", cached.input, legacy.input, tt.fewer)
+			}
+		})
+	}
+}
+
+func TestSelectNextQuestionLeavesGradingMaterialOutOfPrompt(t *testing.T) {
+	provider := llmtest.NewProvider(llmtest.Text(selectionReply))
+	questions := staticQuestions(t)
+	resp, err := provider.Client(testPrompts(t)).SelectNextQuestion(llm.DefaultPromptVersion, questions, sessionHistories(questions, 1)[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.QuestionID != 2 || resp.Feedback != "Good work." {
+		t.Errorf("parsed question %d, feedback %q", resp.QuestionID, resp.Feedback)
+	}
+
+	request := string(provider.Requests()[0])
+	for _, field := range []string{"OptionFeedback", "Hints", "Rubric", "Synonyms", "MaxEdits"} {
+		if strings.Contains(request, `\"`+field+`\"`) {
+			t.Errorf("request includes %s", field)
+		}
+	}
+	for _, q := range questions {
+		for _, hint := range q.Hints {
+			if strings.Contains(request, hint) {
+				t.Errorf("request includes question %d's hint %q", q.ID, hint)
+			}
+		}
+	}
+}
+
+// BenchmarkSelectNextRequest compares the legacy and cached request shapes
+// over a ten-answer session against the replay provider, with the static bank
+// (too short for Haiku 4.5 to cache) and the templated bank. Besides time, it
+// reports tokens per session: uncached input, and cache reads and writes.
+func BenchmarkSelectNextRequest(b *testing.B) {
+	banks := []struct {
+		name      string
+		questions []content.Question
+	}{
+		{"static", staticQuestions(b)},
+		{"templated", templatedQuestions(b)},
+	}
+	for _, bank := range banks {
+		for _, shape := range []string{"legacy", "cached"} {
+			b.Run(bank.name+"/"+shape, func(b *testing.B) {
+				var totals tokenTotals
+				sessions := 0
+				for b.Loop() {
+					t := runSession(b, shape, bank.questions, 10)
+					totals.input += t.input
+					totals.cacheRead += t.cacheRead
+					totals.cacheWrite += t.cacheWrite
+					sessions++
+				}
+				b.ReportMetric(float64(totals.input)/float64(sessions), "input-tokens/session")
+				b.ReportMetric(float64(totals.cacheRead)/float64(sessions), "cache-read-tokens/session")
+				b.ReportMetric(float64(totals.cacheWrite)/float64(sessions), "cache-write-tokens/session")
+			})
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-adapt/internal/content"
+	"strings"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+)
+
+// QuestionGenerationPrompt is the system prompt for drafting new bank questions
+const QuestionGenerationPrompt = `You write multiple-choice questions for an adaptive medical terminology course.
+
+You will be given example questions from the bank, the tags questions may use, a target tag and a target difficulty from 0 (easiest) to 1 (hardest).
+
+Write new questions that:
+- Test the target tag at about the target difficulty, matching the style and difficulty of the examples
+- Are not rewordings of the examples; use different terms, roots or word parts
+- Have exactly one correct option, copied exactly into the answer
+- Have three plausible distractors that reflect real misconceptions, such as confusing similar suffixes or roots
+- Explain the correct answer in the feedback, and explain what each distractor actually means in its option feedback
+- Use only tags from the provided list, including the target tag
+
+Record the questions with the record_questions tool.`
+
+const generateToolName = "record_questions"
+
+// GenerateTimeout bounds one generation call. It is longer than GradeTimeout
+// because a call may write up to authoring.MaxGenerate questions.
+const GenerateTimeout = 60 * time.Second
+
+var generateToolSchema = anthropic.ToolInputSchemaParam{
+	Properties: map[string]any{
+		"questions": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"text":       map[string]any{"type": "string"},
+					"answer":     map[string]any{"type": "string", "description": "Exactly one of the options"},
+					"options":    map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					"difficulty": map[string]any{"type": "number"},
+					"tags":       map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					"feedback":   map[string]any{"type": "string"},
+					"option_feedback": map[string]any{
+						"type":                 "object",
+						"description":          "Feedback for each wrong option, keyed by the option text",
+						"additionalProperties": map[string]any{"type": "string"},
+					},
+				},
+				"required": []string{"text", "answer", "options", "difficulty", "tags", "feedback", "option_feedback"},
+			},
+		},
+	},
+	Required: []string{"questions"},
+}
+
+type generatedQuestion struct {
+	Text           string            `json:"text"`
+	Answer         string            `json:"answer"`
+	Options        []string          `json:"options"`
+	Difficulty     float64           `json:"difficulty"`
+	Tags           []string          `json:"tags"`
+	Feedback       string            `json:"feedback"`
+	OptionFeedback map[string]string `json:"option_feedback"`
+}
+
+// GenerateQuestions drafts count new multiple-choice questions on tag at about
+// difficulty, modeled on examples. The output is unvalidated; see authoring.Generate.
+func (client *LLMClient) GenerateQuestions(ctx context.Context, examples []content.Question, taxonomy []string, tag string, difficulty float64, count int) ([]content.Question, error) {
+	ctx, cancel := context.WithTimeout(ctx, GenerateTimeout)
+	defer cancel()
+
+	examplesJSON, err := json.Marshal(examples)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode examples: %w", err)
+	}
+
+	inputPrompt := fmt.Sprintf(`<examples>
+%s
+</examples>
+
+<tags>
+%s
+</tags>
+
+Write %d new questions for the tag %q at difficulty %.2f.`, examplesJSON, strings.Join(taxonomy, "\n"), count, tag, difficulty)
+
+	tool := anthropic.ToolParam{
+		Name:        generateToolName,
+		Description: anthropic.String("Record the new questions."),
+		InputSchema: generateToolSchema,
+	}
+	message, err := client.Messages.New(ctx, anthropic.MessageNewParams{
+		Model:     anthropic.ModelClaudeHaiku4_5_20251001,
+		MaxTokens: 4096,
+		System:    []anthropic.TextBlockParam{{Text: QuestionGenerationPrompt}},
+		Messages: []anthropic.MessageParam{
+			anthropic.NewUserMessage(anthropic.NewTextBlock(inputPrompt)),
+		},
+		Tools:      []anthropic.ToolUnionParam{{OfTool: &tool}},
+		ToolChoice: anthropic.ToolChoiceParamOfTool(generateToolName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call LLM API: %w", err)
+	}
+
+	for _, block := range message.Content {
+		if block.Type != "tool_use" || block.Name != generateToolName {
+			continue
+		}
+		var input struct {
+			Questions []generatedQuestion `json:"questions"`
+		}
+		if err := json.Unmarshal(block.Input, &input); err != nil {
+			return nil, fmt.Errorf("could not parse generated questions: %w", err)
+		}
+
+		questions := make([]content.Question, 0, len(input.Questions))
+		for _, g := range input.Questions {
+			questions = append(questions, content.Question{
+				Text:    g.Text,
+				Answer:  g.Answer,
+				Options: g.Options,
+				Metadata: content.QuestionMetadata{
+					Difficulty: g.Difficulty,
+					Tags:       g.Tags,
+				},
+				Feedback:       g.Feedback,
+				OptionFeedback: g.OptionFeedback,
+			})
+		}
+		return questions, nil
+	}
+	return nil, fmt.Errorf("no questions in response")
+}
@@ -0,0 +1,46 @@
+package llm_test
+
+import (
+	"context"
+	"errors"
+	"go-adapt/internal/llm/llmtest"
+	"testing"
+	"time"
+)
+
+func TestGenerateQuestionsParsesToolCall(t *testing.T) {
+	provider := llmtest.NewProvider(llmtest.ToolUse("record_questions", map[string]any{
+		"questions": []map[string]any{{
+			"text":            "What does 'nephr/o' mean?",
+			"answer":          "kidney",
+			"options":         []string{"kidney", "liver", "lung", "heart"},
+			"difficulty":      0.3,
+			"tags":            []string{"renal"},
+			"feedback":        "nephr/o is the kidney.",
+			"option_feedback": map[string]string{"liver": "That is hepat/o."},
+		}},
+	}))
+	questions, err := provider.Client(testPrompts(t)).GenerateQuestions(context.Background(), staticQuestions(t)[:2], []string{"renal"}, "renal", 0.3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(questions) != 1 || questions[0].Answer != "kidney" || questions[0].Metadata.Tags[0] != "renal" {
+		t.Errorf("questions = %+v", questions)
+	}
+}
+
+func TestGenerateQuestionsStopsWithContext(t *testing.T) {
+	provider := llmtest.NewProvider(llmtest.ToolUse("record_questions", map[string]any{"questions": []any{}}))
+	provider.Delay = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := provider.Client(testPrompts(t)).GenerateQuestions(ctx, staticQuestions(t)[:2], []string{"renal"}, "renal", 0.3, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("generation took %v after the deadline", elapsed)
+	}
+}
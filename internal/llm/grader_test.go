@@ -0,0 +1,81 @@
+package llm_test
+
+import (
+	"context"
+	"errors"
+	"go-adapt/internal/content"
+	"go-adapt/internal/llm"
+	"go-adapt/internal/llm/llmtest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func explanationQuestion(t *testing.T) *content.Question {
+	t.Helper()
+	q, err := content.NewStaticBank().GetQuestionByID(25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func TestGradeRubricParsesToolCall(t *testing.T) {
+	provider := llmtest.NewProvider(llmtest.ToolUse("record_grade", map[string]any{
+		"criteria": []map[string]any{
+			{"id": "root", "points": 1, "comment": "Named the liver."},
+			{"id": "suffix", "points": 0.5, "comment": "Vague about -megaly."},
+		},
+		"feedback": "Nearly there.",
+	}))
+	q := explanationQuestion(t)
+	criteria, feedback, err := provider.Client(testPrompts(t)).GradeRubric(context.Background(), q, "hepat/o is the liver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(criteria) != 2 || criteria[0].ID != "root" || criteria[1].Points != 0.5 {
+		t.Errorf("criteria = %+v", criteria)
+	}
+	if feedback != "Nearly there." {
+		t.Errorf("feedback = %q", feedback)
+	}
+
+	request := string(provider.Requests()[0])
+	for _, want := range []string{"hepat/o is the liver", `"tool_choice"`, "record_grade", q.Rubric[0].Description} {
+		if !strings.Contains(request, want) {
+			t.Errorf("request doesn't include %s", want)
+		}
+	}
+}
+
+func TestGradeRubricWithoutToolCall(t *testing.T) {
+	client := llmtest.NewProvider(llmtest.Text("Looks good to me.")).Client(testPrompts(t))
+	if _, _, err := client.GradeRubric(context.Background(), explanationQuestion(t), "an answer"); err == nil {
+		t.Error("graded a reply without a record_grade call")
+	}
+}
+
+func TestGradeRubricStopsWithContext(t *testing.T) {
+	provider := llmtest.NewProvider(llmtest.ToolUse("record_grade", map[string]any{"criteria": []any{}, "feedback": ""}))
+	provider.Delay = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := provider.Client(testPrompts(t)).GradeRubric(ctx, explanationQuestion(t), "an answer")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("grading took %v after the deadline", elapsed)
+	}
+}
+
+func TestGradeRubricReturnsAPIErrors(t *testing.T) {
+	client := llmtest.NewProvider(llmtest.Error(500, "overloaded")).Client(testPrompts(t))
+	if _, _, err := client.GradeRubric(context.Background(), explanationQuestion(t), "an answer"); err == nil {
+		t.Error("API error not returned")
+	}
+}
+
+var _ content.RubricGrader = (*llm.LLMClient)(nil)
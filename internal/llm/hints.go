@@ -0,0 +1,101 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-adapt/internal/content"
+	"strings"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+)
+
+// HintGenerationPrompt is the system prompt for writing tiered hints
+const HintGenerationPrompt = `You write hints for questions in an adaptive medical terminology course.
+
+You will be given a question, its options if it has any, and the correct answer. Write tiered hints that a student reveals one at a time:
+- The first hint is a gentle nudge, such as which word part to focus on or what kind of part it is
+- Each later hint gives more away than the one before
+- The last hint nearly gives the answer away but never states it
+- Never name the correct answer or say which option is right
+- Keep each hint to one short sentence
+
+Record the hints with the record_hints tool.`
+
+const hintsToolName = "record_hints"
+
+// HintTimeout bounds one hint generation call, so a hung API call can't hold
+// up every learner waiting on the question's hints
+const HintTimeout = 30 * time.Second
+
+var hintsToolSchema = anthropic.ToolInputSchemaParam{
+	Properties: map[string]any{
+		"hints": map[string]any{
+			"type":        "array",
+			"description": "Hints in the order they are revealed, least revealing first",
+			"items":       map[string]any{"type": "string"},
+		},
+	},
+	Required: []string{"hints"},
+}
+
+// GenerateHints writes count hint tiers for a question without authored hints
+func (client *LLMClient) GenerateHints(ctx context.Context, q *content.Question, count int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, HintTimeout)
+	defer cancel()
+
+	answer := q.Answer
+	if len(q.Answers) > 0 {
+		answer = strings.Join(q.Answers, "; ")
+	}
+	inputPrompt := fmt.Sprintf(`<question>
+%s
+</question>
+
+<options>
+%s
+</options>
+
+<answer>
+%s
+</answer>
+
+Write %d hints.`, q.Text, strings.Join(q.Options, "\n"), answer, count)
+
+	tool := anthropic.ToolParam{
+		Name:        hintsToolName,
+		Description: anthropic.String("Record the hints, least revealing first."),
+		InputSchema: hintsToolSchema,
+	}
+	message, err := client.Messages.New(ctx, anthropic.MessageNewParams{
+		Model:     anthropic.ModelClaudeHaiku4_5_20251001,
+		MaxTokens: 1024,
+		System:    []anthropic.TextBlockParam{{Text: HintGenerationPrompt}},
+		Messages: []anthropic.MessageParam{
+			anthropic.NewUserMessage(anthropic.NewTextBlock(inputPrompt)),
+		},
+		Tools:      []anthropic.ToolUnionParam{{OfTool: &tool}},
+		ToolChoice: anthropic.ToolChoiceParamOfTool(hintsToolName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call LLM API: %w", err)
+	}
+
+	for _, block := range message.Content {
+		if block.Type != "tool_use" || block.Name != hintsToolName {
+			continue
+		}
+		var input struct {
+			Hints []string `json:"hints"`
+		}
+		if err := json.Unmarshal(block.Input, &input); err != nil {
+			return nil, fmt.Errorf("could not parse hints: %w", err)
+		}
+		if len(input.Hints) > count {
+			input.Hints = input.Hints[:count]
+		}
+		return input.Hints, nil
+	}
+	return nil, fmt.Errorf("no hints in response")
+}
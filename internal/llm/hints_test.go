@@ -0,0 +1,40 @@
+package llm_test
+
+import (
+	"context"
+	"errors"
+	"go-adapt/internal/content"
+	"go-adapt/internal/llm/llmtest"
+	"testing"
+	"time"
+)
+
+func TestGenerateHintsParsesToolCall(t *testing.T) {
+	provider := llmtest.NewProvider(llmtest.ToolUse("record_hints", map[string]any{
+		"hints": []string{"Look at the root.", "It names an organ.", "The organ filters blood.", "One too many."},
+	}))
+	q := &content.Question{ID: 1, Text: "What does 'nephr/o' mean?", Options: []string{"kidney", "liver"}, Answer: "kidney"}
+	hints, err := provider.Client(testPrompts(t)).GenerateHints(context.Background(), q, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hints) != 3 || hints[0] != "Look at the root." {
+		t.Errorf("hints = %v, want the first three", hints)
+	}
+}
+
+func TestGenerateHintsStopsWithContext(t *testing.T) {
+	provider := llmtest.NewProvider(llmtest.ToolUse("record_hints", map[string]any{"hints": []string{"nudge"}}))
+	provider.Delay = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := provider.Client(testPrompts(t)).GenerateHints(ctx, &content.Question{ID: 1, Text: "?"}, 3)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("generation took %v after the deadline", elapsed)
+	}
+}
@@ -0,0 +1,220 @@
+// Package llmtest provides a fake Anthropic Messages API for tests and
+// benchmarks, so LLM-backed code runs without a network or an API key.
+package llmtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-adapt/internal/llm"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go/option"
+)
+
+// Response is one canned reply: message content blocks, or an API error when Status is set
+type Response struct {
+	Status  int
+	Message string // Error message, for error responses
+	Content []map[string]any
+}
+
+// Text replies with a single text block
+func Text(text string) Response {
+	return Response{Content: []map[string]any{{"type": "text", "text": text}}}
+}
+
+// ToolUse replies with a call to the named tool, as a forced tool choice would
+func ToolUse(name string, input any) Response {
+	return Response{Content: []map[string]any{{"type": "tool_use", "id": "toolu_test", "name": name, "input": input}}}
+}
+
+// Error replies with an API error of the given HTTP status
+func Error(status int, message string) Response {
+	return Response{Status: status, Message: message}
+}
+
+// Provider replays canned responses in order, repeating the last one once they
+// run out, and records every request. Usage is estimated from the request at
+// about four bytes a token, and prompt caching is simulated: the system blocks
+// up to the last cache breakpoint are a cache write the first time a prefix is
+// seen and a cache read after that, provided the prefix reaches the model's
+// minimum cacheable length. Shorter prefixes are billed as ordinary input, as
+// the API does.
+type Provider struct {
+	Delay time.Duration // Added to every call, to stand in for network latency
+
+	mu        sync.Mutex
+	responses []Response
+	requests  [][]byte
+	prefixes  map[string]bool
+}
+
+func NewProvider(responses ...Response) *Provider {
+	return &Provider{responses: responses, prefixes: make(map[string]bool)}
+}
+
+// Client returns an LLM client that sends every request to the provider
+func (p *Provider) Client(prompts *llm.PromptSet) *llm.LLMClient {
+	return llm.NewLLMClient("test-key", prompts,
+		option.WithBaseURL("http://llmtest.invalid/"),
+		option.WithHTTPClient(&http.Client{Transport: p}),
+		option.WithMaxRetries(0),
+	)
+}
+
+// Calls is how many requests the provider has answered
+func (p *Provider) Calls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.requests)
+}
+
+// Requests returns the body of every request so far, oldest first
+func (p *Provider) Requests() [][]byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([][]byte(nil), p.requests...)
+}
+
+// minCacheTokens is the shortest prefix each model will cache. Models not
+// listed use defaultMinCacheTokens.
+var minCacheTokens = map[string]int{
+	"claude-haiku-4-5":          4096,
+	"claude-haiku-4-5-20251001": 4096,
+}
+
+const defaultMinCacheTokens = 1024
+
+// MinCacheTokens is the shortest prefix, in tokens, the provider caches for model
+func MinCacheTokens(model string) int {
+	if n, ok := minCacheTokens[model]; ok {
+		return n
+	}
+	return defaultMinCacheTokens
+}
+
+type request struct {
+	Model    string  `json:"model"`
+	System   []block `json:"system"`
+	Messages []struct {
+		Content []block `json:"content"`
+	} `json:"messages"`
+}
+
+type block struct {
+	Text         string          `json:"text"`
+	CacheControl json.RawMessage `json:"cache_control"`
+}
+
+func (p *Provider) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if p.Delay > 0 {
+		select {
+		case <-time.After(p.Delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, body)
+	if len(p.responses) == 0 {
+		return reply(req, http.StatusInternalServerError, apiError("no canned responses"))
+	}
+	resp := p.responses[min(len(p.requests), len(p.responses))-1]
+	if resp.Status != 0 {
+		return reply(req, resp.Status, apiError(resp.Message))
+	}
+
+	var parsed request
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return reply(req, http.StatusBadRequest, apiError(err.Error()))
+	}
+	usage := p.usage(parsed)
+	return reply(req, http.StatusOK, map[string]any{
+		"id":            fmt.Sprintf("msg_test_%d", len(p.requests)),
+		"type":          "message",
+		"role":          "assistant",
+		"model":         parsed.Model,
+		"content":       resp.Content,
+		"stop_reason":   "end_turn",
+		"stop_sequence": nil,
+		"usage":         usage,
+	})
+}
+
+func (p *Provider) usage(req request) map[string]int {
+	var prefix, rest strings.Builder
+	cached := 0 // System blocks up to and including the last breakpoint
+	for i, b := range req.System {
+		if len(b.CacheControl) > 0 && string(b.CacheControl) != "null" {
+			cached = i + 1
+		}
+	}
+	for i, b := range req.System {
+		if i < cached {
+			prefix.WriteString(b.Text)
+		} else {
+			rest.WriteString(b.Text)
+		}
+	}
+	for _, m := range req.Messages {
+		for _, b := range m.Content {
+			rest.WriteString(b.Text)
+		}
+	}
+
+	usage := map[string]int{
+		"input_tokens":                tokens(rest.Len()),
+		"output_tokens":               100,
+		"cache_read_input_tokens":     0,
+		"cache_creation_input_tokens": 0,
+	}
+	switch {
+	case prefix.Len() == 0:
+	case tokens(prefix.Len()) < MinCacheTokens(req.Model):
+		usage["input_tokens"] += tokens(prefix.Len())
+	default:
+		if p.prefixes[prefix.String()] {
+			usage["cache_read_input_tokens"] = tokens(prefix.Len())
+		} else {
+			usage["cache_creation_input_tokens"] = tokens(prefix.Len())
+			p.prefixes[prefix.String()] = true
+		}
+	}
+	return usage
+}
+
+func tokens(n int) int {
+	return (n + 3) / 4
+}
+
+func apiError(message string) map[string]any {
+	return map[string]any{
+		"type":  "error",
+		"error": map[string]any{"type": "api_error", "message": message},
+	}
+}
+
+func reply(req *http.Request, status int, body any) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package llm
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// DefaultPrompts holds the prompt templates shipped with the binary.
+//
+//go:embed prompts/*.tmpl
+var DefaultPrompts embed.FS
+
+// DefaultPromptVersion is used when a session does not request a specific prompt.
+const DefaultPromptVersion = "v1"
+
+// PromptVars are the values substituted into every prompt template.
+type PromptVars struct {
+	CourseName    string
+	DifficultyMin float64
+	DifficultyMax float64
+	OutputSchema  string
+}
+
+func DefaultPromptVars() PromptVars {
+	return PromptVars{
+		CourseName:    "medical terminology",
+		DifficultyMin: 0.1,
+		DifficultyMax: 0.9,
+		OutputSchema:  OutputSchema,
+	}
+}
+
+// PromptSet is the rendered system prompt for each available version.
+// Templates are rendered once at load time so every call in a session sends a
+// byte-identical system prompt and keeps hitting the prompt cache.
+type PromptSet struct {
+	prompts map[string]string
+}
+
+// LoadPrompts renders every *.tmpl file at the root of fsys. The file name
+// without extension is the version, e.g. prompts/v2.tmpl -> "v2".
+func LoadPrompts(fsys fs.FS, vars PromptVars) (*PromptSet, error) {
+	files, err := fs.Glob(fsys, "*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no prompt templates found")
+	}
+
+	ps := &PromptSet{prompts: make(map[string]string, len(files))}
+	for _, file := range files {
+		raw, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt %s: %w", file, err)
+		}
+
+		tmpl, err := template.New(file).Option("missingkey=error").Parse(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse prompt %s: %w", file, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, vars); err != nil {
+			return nil, fmt.Errorf("failed to render prompt %s: %w", file, err)
+		}
+
+		version := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		ps.prompts[version] = buf.String()
+	}
+	return ps, nil
+}
+
+// LoadDefaultPrompts renders the embedded templates with the default variables.
+func LoadDefaultPrompts() (*PromptSet, error) {
+	sub, err := fs.Sub(DefaultPrompts, "prompts")
+	if err != nil {
+		return nil, err
+	}
+	return LoadPrompts(sub, DefaultPromptVars())
+}
+
+func (ps *PromptSet) Get(version string) (string, error) {
+	prompt, ok := ps.prompts[version]
+	if !ok {
+		return "", fmt.Errorf("unknown prompt version %q", version)
+	}
+	return prompt, nil
+}
+
+func (ps *PromptSet) Has(version string) bool {
+	_, ok := ps.prompts[version]
+	return ok
+}
+
+// Versions returns the available prompt versions in sorted order.
+func (ps *PromptSet) Versions() []string {
+	versions := make([]string, 0, len(ps.prompts))
+	for v := range ps.prompts {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return versions
+}
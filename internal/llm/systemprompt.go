@@ -1,59 +1,9 @@
 package llm
 
-const LLMGuidedPrompt string = `You are an adaptive learning system that analyzes student performance and selects optimal next questions to maximize learning. Your goal is to keep students in their "Zone of Proximal Development" - challenging them appropriately without causing frustration or boredom.
-
-The question bank will come in the format:
-
-<question_bank>
-</question_bank>
-
-Each question has:
-- ID: A unique identifier
-- Text: The question content
-- Answer: The correct answer
-- Difficulty: A value from 0.1 (easiest) to 0.9 (hardest)
-- Tags: Topic/concept tags for the question
-
-The student's answers will come in the format:
-
-<answer_history>
-</answer_history>
-
-Each answer record contains:
-- QuestionID: Which question was answered
-- Correct: Boolean indicating if the answer was correct
-
-Your task has three components:
-
-**1. ANALYZE STUDENT MASTERY**
-
-In your analysis, consider:
-- Overall success rate
-- Performance patterns by difficulty level (are they succeeding at their current level?)
-- Performance patterns by topic/tag (are there specific misconceptions?)
-- Recent trajectory (improving, plateauing, or struggling?)
-- Estimated current mastery level (what difficulty range suits them?)
-
-**2. SELECT NEXT QUESTION**
-
-Apply these principles:
-- Target the student's Zone of Proximal Development: slightly above their current demonstrated mastery
-- If the student is succeeding consistently (e.g., 70%+ correct at current difficulty), increase difficulty by 0.1-0.2
-- If the student is struggling (e.g., below 50% correct), decrease difficulty by 0.1-0.2
-- Avoid repeating recently asked questions
-- If patterns show topic-specific struggles, consider selecting questions on that topic at an appropriate difficulty
-- Balance between reinforcing weak areas and building on strengths
-
-**3. GENERATE PERSONALIZED FEEDBACK**
-
-For the most recent answer in the history:
-- Explain why the answer was correct or incorrect
-- If incorrect, identify the likely misconception based on the pattern of errors
-- Provide encouragement appropriate to their performance trajectory
-- If they're struggling, offer more detailed explanations; if they're excelling, keep feedback concise
-- Connect feedback to broader patterns you've observed in their learning
-
-**OUTPUT FORMAT**
+// OutputSchema is the response format every prompt template must request via
+// {{.OutputSchema}}. It lives in Go rather than in the templates because the
+// parsers in client.go depend on these exact tags.
+const OutputSchema string = `**OUTPUT FORMAT**
 Instead of using markdown decorators, use <b></b> for bold and <i></i> for italics.
 Provide your response in the following format:
 
@@ -0,0 +1,83 @@
+package review
+
+import (
+	"math"
+	"time"
+)
+
+// SM-2 spaced repetition scheduling. Each (learner, question) pair has a card
+// recording how well the item is retained and when it should next be reviewed.
+
+const (
+	initialEase = 2.5
+	minEase     = 1.3
+
+	// SM-2 quality grades (0-5) for full and no credit; partial credit falls
+	// between them, and grades from qualityPass up count as recalled
+	qualityCorrect   = 4
+	qualityIncorrect = 1
+	qualityPass      = 3
+
+	day = 24 * time.Hour
+)
+
+type Card struct {
+	QuestionID   int       `json:"question_id"`
+	Ease         float64   `json:"ease"`        // SM-2 easiness factor, >= 1.3
+	Stability    float64   `json:"stability"`   // Current interval in days
+	Repetitions  int       `json:"repetitions"` // Consecutive successful reviews
+	Lapses       int       `json:"lapses"`      // Times the item was forgotten after being learned
+	Due          time.Time `json:"due"`
+	LastReviewed time.Time `json:"last_reviewed"`
+}
+
+func NewCard(questionID int) Card {
+	return Card{
+		QuestionID: questionID,
+		Ease:       initialEase,
+	}
+}
+
+// IsDue reports whether the card should be reviewed at now
+func (c Card) IsDue(now time.Time) bool {
+	return !c.Due.After(now)
+}
+
+// Quality maps an answer's score, from 0 (no credit) to 1 (full credit), onto
+// an SM-2 quality grade. Scores from 0.5 up count as recalled.
+func Quality(score float64) int {
+	score = math.Max(0, math.Min(1, score))
+	return int(math.Round(qualityIncorrect + score*(qualityCorrect-qualityIncorrect)))
+}
+
+// Schedule returns the card after a review at now graded quality (0-5)
+func Schedule(c Card, quality int, now time.Time) Card {
+	q := float64(quality)
+
+	if quality >= qualityPass {
+		switch c.Repetitions {
+		case 0:
+			c.Stability = 1
+		case 1:
+			c.Stability = 6
+		default:
+			c.Stability = math.Round(c.Stability * c.Ease)
+		}
+		c.Repetitions++
+	} else {
+		if c.Repetitions > 0 {
+			c.Lapses++
+		}
+		c.Repetitions = 0
+		c.Stability = 1
+	}
+
+	c.Ease += 0.1 - (5-q)*(0.08+(5-q)*0.02)
+	if c.Ease < minEase {
+		c.Ease = minEase
+	}
+
+	c.LastReviewed = now
+	c.Due = now.Add(time.Duration(c.Stability * float64(day)))
+	return c
+}
@@ -0,0 +1,89 @@
+package review
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	steps := []struct {
+		score       float64
+		stability   float64
+		repetitions int
+		lapses      int
+		ease        float64
+	}{
+		{1, 1, 1, 0, 2.5},  // First success: one day
+		{1, 6, 2, 0, 2.5},  // Second: six days
+		{1, 15, 3, 0, 2.5}, // Then interval × ease
+		{0, 1, 0, 1, 1.96},
+		{1, 1, 1, 1, 1.96}, // Relearning starts over
+		{1, 6, 2, 1, 1.96},
+		{0.5, 12, 3, 1, 1.82}, // Half credit is recalled, at quality 3: round(6 × 1.96), ease falls
+		{0.25, 1, 0, 2, 1.5},  // Quarter credit, quality 2, is a lapse
+		{0, 1, 0, 2, 1.3},     // A miss before relearning isn't another lapse; ease bottoms out
+		{0, 1, 0, 2, 1.3},
+	}
+
+	card := NewCard(7)
+	now := start
+	for i, step := range steps {
+		card = Schedule(card, Quality(step.score), now)
+		if card.Stability != step.stability || card.Repetitions != step.repetitions || card.Lapses != step.lapses || math.Abs(card.Ease-step.ease) > 1e-9 {
+			t.Fatalf("review %d: stability %v, repetitions %d, lapses %d, ease %v; want %v, %d, %d, %v",
+				i+1, card.Stability, card.Repetitions, card.Lapses, card.Ease, step.stability, step.repetitions, step.lapses, step.ease)
+		}
+		if want := now.Add(time.Duration(step.stability) * day); !card.Due.Equal(want) || !card.LastReviewed.Equal(now) {
+			t.Fatalf("review %d: due %v after review at %v, want %v", i+1, card.Due, card.LastReviewed, want)
+		}
+		if card.QuestionID != 7 {
+			t.Fatalf("review %d changed the question ID to %d", i+1, card.QuestionID)
+		}
+		now = card.Due
+	}
+}
+
+func TestQuality(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  int
+	}{
+		{0, 1},
+		{0.25, 2},
+		{0.5, 3},
+		{0.75, 3},
+		{1, 4},
+		{-1, 1}, // Out-of-range scores are clamped
+		{2, 4},
+	}
+	for _, tt := range tests {
+		if got := Quality(tt.score); got != tt.want {
+			t.Errorf("Quality(%v) = %d, want %d", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestIsDue(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	card := Schedule(NewCard(1), Quality(1), now)
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"right after review", now, false},
+		{"just before due", card.Due.Add(-time.Second), false},
+		{"when due", card.Due, true},
+		{"overdue", card.Due.Add(48 * time.Hour), true},
+	}
+	for _, tt := range tests {
+		if got := card.IsDue(tt.at); got != tt.want {
+			t.Errorf("%s: IsDue = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if !NewCard(2).IsDue(now) {
+		t.Error("a new card isn't due")
+	}
+}
@@ -0,0 +1,169 @@
+package review
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-adapt/internal/atomicfile"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Store persists review cards per learner between sessions. UpdateCard runs
+// update on the learner's card for questionID (a NewCard if there is none yet)
+// and stores the result, holding the store's lock throughout, so concurrent
+// sessions for one learner never overwrite each other's reviews.
+type Store interface {
+	Cards(learnerID string) (map[int]Card, error)
+	UpdateCard(learnerID string, questionID int, update func(Card) Card) (Card, error)
+}
+
+// FileStore keeps every learner's cards in one JSON file, rewritten on each save.
+// It is safe for concurrent use within a single process.
+type FileStore struct {
+	mu    sync.RWMutex
+	path  string
+	cards map[string]map[int]Card
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	fs := &FileStore{
+		path:  path,
+		cards: make(map[string]map[int]Card),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read review store: %w", err)
+	}
+	if err := json.Unmarshal(data, &fs.cards); err != nil {
+		return nil, fmt.Errorf("failed to parse review store %s: %w", path, err)
+	}
+	return fs, nil
+}
+
+// Cards returns a copy of the learner's cards keyed by question ID
+func (fs *FileStore) Cards(learnerID string) (map[int]Card, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	cards := make(map[int]Card, len(fs.cards[learnerID]))
+	for id, card := range fs.cards[learnerID] {
+		cards[id] = card
+	}
+	return cards, nil
+}
+
+// SaveCard stores the card, replacing any the learner has for its question
+func (fs *FileStore) SaveCard(learnerID string, card Card) error {
+	_, err := fs.UpdateCard(learnerID, card.QuestionID, func(Card) Card { return card })
+	return err
+}
+
+// UpdateCard updates the card and rewrites the file. If the write fails the
+// update isn't kept, so memory never gets ahead of the file.
+func (fs *FileStore) UpdateCard(learnerID string, questionID int, update func(Card) Card) (Card, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.cards[learnerID] == nil {
+		fs.cards[learnerID] = make(map[int]Card)
+	}
+	previous, existed := fs.cards[learnerID][questionID]
+	card := previous
+	if !existed {
+		card = NewCard(questionID)
+	}
+	card = update(card)
+	fs.cards[learnerID][questionID] = card
+
+	if err := fs.save(); err != nil {
+		if existed {
+			fs.cards[learnerID][questionID] = previous
+		} else {
+			delete(fs.cards[learnerID], questionID)
+		}
+		return Card{}, err
+	}
+	return card, nil
+}
+
+// save rewrites the store file atomically
+func (fs *FileStore) save() error {
+	data, err := json.MarshalIndent(fs.cards, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := atomicfile.WriteFile(fs.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write review store: %w", err)
+	}
+	return nil
+}
+
+// Upcoming returns the learner's cards ordered by due date, soonest first
+func Upcoming(store Store, learnerID string) ([]Card, error) {
+	cards, err := store.Cards(learnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming := make([]Card, 0, len(cards))
+	for _, card := range cards {
+		upcoming = append(upcoming, card)
+	}
+	sort.Slice(upcoming, func(i, j int) bool {
+		if upcoming[i].Due.Equal(upcoming[j].Due) {
+			return upcoming[i].QuestionID < upcoming[j].QuestionID
+		}
+		return upcoming[i].Due.Before(upcoming[j].Due)
+	})
+	return upcoming, nil
+}
+
+// MemoryStore keeps cards in memory only, for simulations and tests of review scheduling
+type MemoryStore struct {
+	mu    sync.RWMutex
+	cards map[string]map[int]Card
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		cards: make(map[string]map[int]Card),
+	}
+}
+
+func (ms *MemoryStore) Cards(learnerID string) (map[int]Card, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	cards := make(map[int]Card, len(ms.cards[learnerID]))
+	for id, card := range ms.cards[learnerID] {
+		cards[id] = card
+	}
+	return cards, nil
+}
+
+func (ms *MemoryStore) SaveCard(learnerID string, card Card) error {
+	_, err := ms.UpdateCard(learnerID, card.QuestionID, func(Card) Card { return card })
+	return err
+}
+
+func (ms *MemoryStore) UpdateCard(learnerID string, questionID int, update func(Card) Card) (Card, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if ms.cards[learnerID] == nil {
+		ms.cards[learnerID] = make(map[int]Card)
+	}
+	card, ok := ms.cards[learnerID][questionID]
+	if !ok {
+		card = NewCard(questionID)
+	}
+	card = update(card)
+	ms.cards[learnerID][questionID] = card
+	return card, nil
+}
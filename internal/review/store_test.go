@@ -0,0 +1,116 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestFileStoreSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "reviews.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, card := range []Card{NewCard(1), NewCard(2)} {
+		if err := store.SaveCard("ada", card); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.SaveCard("ben", NewCard(3)); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for learner, want := range map[string]int{"ada": 2, "ben": 1, "cal": 0} {
+		cards, err := reopened.Cards(learner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cards) != want {
+			t.Errorf("%s has %d cards after reopening, want %d", learner, len(cards), want)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("store directory holds %d files, want only the store (temp files left behind?)", len(entries))
+	}
+}
+
+func TestFileStoreFailedSaveKeepsState(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "reviews.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveCard("ada", NewCard(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Renaming over a non-empty directory fails, so every later save does
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(path, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	updated := NewCard(1)
+	updated.Repetitions = 5
+	if err := store.SaveCard("ada", updated); err == nil {
+		t.Fatal("save over a directory succeeded")
+	}
+	if err := store.SaveCard("ada", NewCard(2)); err == nil {
+		t.Fatal("save over a directory succeeded")
+	}
+
+	cards, _ := store.Cards("ada")
+	if len(cards) != 1 || cards[1].Repetitions != 0 {
+		t.Errorf("cards after failed saves = %+v, want only the saved card unchanged", cards)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d entries after failed saves, want no temp files", len(entries))
+	}
+}
+
+func TestUpdateCardSerializesReviews(t *testing.T) {
+	file, err := NewFileStore(filepath.Join(t.TempDir(), "reviews.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, store := range map[string]Store{"file": file, "memory": NewMemoryStore()} {
+		t.Run(name, func(t *testing.T) {
+			const reviews = 20
+			var wg sync.WaitGroup
+			for range reviews {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := store.UpdateCard("ada", 1, func(card Card) Card {
+						card.Repetitions++
+						return card
+					})
+					if err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			cards, _ := store.Cards("ada")
+			if cards[1].Repetitions != reviews || cards[1].Ease != initialEase {
+				t.Errorf("card after %d concurrent updates = %+v, want every update kept on a new card", reviews, cards[1])
+			}
+		})
+	}
+}
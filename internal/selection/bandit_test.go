@@ -0,0 +1,97 @@
+package selection
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBanditFileStoreSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bandit.json")
+	store, err := NewBanditFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []struct {
+		id     int
+		reward float64
+	}{{1, 0.25}, {1, 0.125}, {2, -0.5}} {
+		if err := store.Record(r.id, r.reward); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reopened, err := NewBanditFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	arms, pulls := reopened.Snapshot()
+	want := map[int]ArmStats{1: {Pulls: 2, TotalReward: 0.375}, 2: {Pulls: 1, TotalReward: -0.5}}
+	if pulls != 3 || !reflect.DeepEqual(arms, want) {
+		t.Errorf("reopened store has %d pulls and arms %+v, want 3 and %+v", pulls, arms, want)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("store directory holds %d files, want only the store (temp files left behind?)", len(entries))
+	}
+}
+
+func TestBanditFileStoreFailedSaveKeepsState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bandit.json")
+	store, err := NewBanditFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Record(1, 0.25); err != nil {
+		t.Fatal(err)
+	}
+
+	// Renaming over a non-empty directory fails, so every later save does
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(path, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Record(1, 0.5); err == nil {
+		t.Fatal("record saved over a directory")
+	}
+	if err := store.Record(2, 0.5); err == nil {
+		t.Fatal("record saved over a directory")
+	}
+
+	arms, pulls := store.Snapshot()
+	if want := map[int]ArmStats{1: {Pulls: 1, TotalReward: 0.25}}; pulls != 1 || !reflect.DeepEqual(arms, want) {
+		t.Errorf("store after failed saves has %d pulls and arms %+v, want 1 and %+v", pulls, arms, want)
+	}
+}
+
+func TestBanditStoreCopyIsIndependent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bandit.json")
+	store, err := NewBanditFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Record(1, 0.25); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	copied := store.Copy()
+	if arms, pulls := copied.Snapshot(); pulls != 1 || arms[1].Pulls != 1 {
+		t.Errorf("copy has %d pulls and arms %+v, want the learned statistics", pulls, arms)
+	}
+	if err := copied.Record(1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, pulls := store.Snapshot(); pulls != 1 {
+		t.Errorf("recording on the copy changed the original: %d pulls", pulls)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Error("recording on the copy rewrote the original's file")
+	}
+}
@@ -0,0 +1,206 @@
+package selection
+
+import (
+	"fmt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/irt"
+	"go-adapt/internal/llm"
+)
+
+// HYBRID PSYCHOMETRIC + LLM SELECTION
+//
+// IRT narrows the bank to the few unanswered questions with the most Fisher
+// information at the learner's ability estimate; the LLM then picks one of
+// those and justifies it. The LLM adds pedagogy but can't stray from the
+// psychometric target.
+
+const defaultCandidateCount = 5
+
+// HybridDecision records one selection: what IRT proposed and what the LLM chose
+type HybridDecision struct {
+	Candidates  []llm.Candidate
+	Chosen      int
+	Reasoning   string
+	LLMFallback bool // LLM chose outside the candidates (or failed), so the top candidate was used
+}
+
+type Hybrid struct {
+	questionBank   content.QuestionBank
+	llmClient      *llm.LLMClient
+	promptVersion  string
+	candidateCount int
+	cachedResult   *SelectionResult
+	decisions      []HybridDecision
+	lastFeedback   string
+	lastUserModel  *llm.UserModel
+}
+
+func NewHybrid(qb content.QuestionBank, client *llm.LLMClient, promptVersion string, candidateCount int) *Hybrid {
+	if candidateCount <= 0 {
+		candidateCount = defaultCandidateCount
+	}
+	return &Hybrid{
+		questionBank:   qb,
+		llmClient:      client,
+		promptVersion:  promptVersion,
+		candidateCount: candidateCount,
+	}
+}
+
+func (h *Hybrid) SelectQuestion(ctx SelectionContext) (*SelectionResult, error) {
+	if h.cachedResult != nil {
+		result := h.cachedResult
+		h.cachedResult = nil
+		return result, nil
+	}
+
+	// First question (or a missed prepare): use the top IRT candidate without calling the LLM
+	candidates, err := h.candidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	question, err := h.questionBank.GetQuestionByID(candidates[0].QuestionID)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := HybridDecision{
+		Candidates: candidates,
+		Chosen:     question.ID,
+		Reasoning:  "Building your initial learner model with the most informative question for a new learner.",
+	}
+	if len(ctx.History) > 0 {
+		decision.LLMFallback = true
+		decision.Reasoning = "Selected the most informative question for your current ability estimate."
+	}
+	h.decisions = append(h.decisions, decision)
+	return &SelectionResult{
+		Question:           question,
+		SelectionReasoning: decision.Reasoning,
+	}, nil
+}
+
+// PrepareNextQuestion ranks candidates with IRT, then asks the LLM to choose among them
+func (h *Hybrid) PrepareNextQuestion(ctx SelectionContext) error {
+	// Nothing from the previous answer may outlive a failed call
+	h.cachedResult = nil
+	h.lastFeedback = ""
+
+	allQuestions, err := h.questionBank.GetAll()
+	if err != nil {
+		return err
+	}
+	candidates, err := h.candidates(ctx)
+	if err != nil {
+		return err
+	}
+
+	llmResponse, err := h.llmClient.SelectFromCandidates(h.promptVersion, allQuestions, ctx.History, candidates, "fisher_information")
+	if err != nil {
+		return err
+	}
+	ctx.reportLLMCall(llmResponse)
+
+	decision := HybridDecision{
+		Candidates: candidates,
+		Chosen:     llmResponse.QuestionID,
+		Reasoning:  llmResponse.SelectionReasoning,
+	}
+	if !containsCandidate(candidates, llmResponse.QuestionID) {
+		decision.Chosen = candidates[0].QuestionID
+		decision.LLMFallback = true
+		decision.Reasoning = fmt.Sprintf("Selected the most informative question (the suggested question %d was not a candidate).", llmResponse.QuestionID)
+	}
+	h.decisions = append(h.decisions, decision)
+
+	question, err := h.questionBank.GetQuestionByID(decision.Chosen)
+	if err != nil {
+		return err
+	}
+
+	h.cachedResult = &SelectionResult{
+		Question:           question,
+		Feedback:           llmResponse.Feedback,
+		SelectionReasoning: decision.Reasoning,
+		UserModel:          llmResponse.UserModel,
+	}
+	h.lastFeedback = llmResponse.Feedback
+	if llmResponse.UserModel != nil {
+		h.lastUserModel = llmResponse.UserModel
+	}
+	return nil
+}
+
+func (h *Hybrid) Feedback() string {
+	return h.lastFeedback
+}
+
+func (h *Hybrid) LearnerModel() *LearnerSnapshot {
+	return userModelSnapshot(h.lastUserModel)
+}
+
+// Metrics include the IRT candidate set and the LLM's final choice for every question
+func (h *Hybrid) Metrics() map[string]interface{} {
+	decisions := make([]map[string]interface{}, 0, len(h.decisions))
+	for _, d := range h.decisions {
+		candidates := make([]map[string]interface{}, 0, len(d.Candidates))
+		for _, c := range d.Candidates {
+			candidates = append(candidates, map[string]interface{}{
+				"question_id": c.QuestionID,
+				"information": c.Score,
+			})
+		}
+		decisions = append(decisions, map[string]interface{}{
+			"candidates":   candidates,
+			"chosen":       d.Chosen,
+			"reasoning":    d.Reasoning,
+			"llm_fallback": d.LLMFallback,
+		})
+	}
+
+	metrics := map[string]interface{}{
+		"hybrid_decisions": decisions,
+		"prompt_version":   h.promptVersion,
+	}
+	if h.lastUserModel != nil {
+		metrics["user_model"] = userModelSnapshot(h.lastUserModel).Values
+	}
+	return metrics
+}
+
+// candidates returns the unanswered questions with the most information at the current ability estimate
+func (h *Hybrid) candidates(ctx SelectionContext) ([]llm.Candidate, error) {
+	allQuestions, err := h.questionBank.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	unanswered := filterUnanswered(allQuestions, ctx.Answered)
+	if len(unanswered) == 0 {
+		return nil, fmt.Errorf("no unanswered questions left")
+	}
+
+	responses, err := irtResponses(h.questionBank, ctx.History)
+	if err != nil {
+		return nil, err
+	}
+	theta, _ := irt.EstimateEAP(responses)
+
+	ranked := rankByInformation(unanswered, theta)
+	candidates := make([]llm.Candidate, 0, h.candidateCount)
+	for i := 0; i < len(ranked) && i < h.candidateCount; i++ {
+		candidates = append(candidates, llm.Candidate{
+			QuestionID: ranked[i].ID,
+			Score:      irt.Information(theta, irtItem(&ranked[i])),
+		})
+	}
+	return candidates, nil
+}
+
+func containsCandidate(candidates []llm.Candidate, questionID int) bool {
+	for _, c := range candidates {
+		if c.QuestionID == questionID {
+			return true
+		}
+	}
+	return false
+}
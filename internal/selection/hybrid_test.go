@@ -0,0 +1,49 @@
+package selection
+
+import (
+	"go-adapt/internal/content"
+	"go-adapt/internal/llm"
+	"go-adapt/internal/llm/llmtest"
+	"strings"
+	"testing"
+)
+
+func TestHybridFallbackReasoningAfterFailedCall(t *testing.T) {
+	prompts, err := llm.LoadDefaultPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bank := content.NewStaticBank()
+	provider := llmtest.NewProvider(llmtest.Error(500, "overloaded"))
+	h := NewHybrid(bank, provider.Client(prompts), llm.DefaultPromptVersion, 3)
+
+	first, err := h.SelectQuestion(SelectionContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(first.SelectionReasoning, "initial") {
+		t.Errorf("first question reasoning %q, want the initial-model message", first.SelectionReasoning)
+	}
+
+	ctx := SelectionContext{
+		Answered: []int{first.Question.ID},
+		History:  []content.AnswerRecord{{QuestionID: first.Question.ID, Correct: true, Score: 1}},
+	}
+	if err := h.PrepareNextQuestion(ctx); err == nil {
+		t.Fatal("PrepareNextQuestion succeeded against a failing provider")
+	}
+	second, err := h.SelectQuestion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(second.SelectionReasoning, "initial") {
+		t.Errorf("second question reasoning %q still describes the first question", second.SelectionReasoning)
+	}
+	if second.Question.ID == first.Question.ID {
+		t.Error("served an answered question again")
+	}
+	decisions := h.Metrics()["hybrid_decisions"].([]map[string]interface{})
+	if fallback := decisions[len(decisions)-1]["llm_fallback"]; fallback != true {
+		t.Errorf("last decision llm_fallback = %v, want true", fallback)
+	}
+}
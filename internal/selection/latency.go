@@ -0,0 +1,76 @@
+package selection
+
+import (
+	"fmt"
+	"go-adapt/internal/bkt"
+	"go-adapt/internal/content"
+	"time"
+)
+
+// LATENCY-AWARE BKT
+//
+// Like rule-based selection, but the difficulty target is the selector's own
+// BKT estimate, which also weighs how long each answer took: fluent answers
+// count for more, slow answers and rapid guesses for less.
+
+type LatencyAware struct {
+	noCapabilities
+	questionBank content.QuestionBank
+	model        *bkt.LatencyBKT
+	picker       *picker
+}
+
+func NewLatencyAware(bank content.QuestionBank, params bkt.LatencyParams, l0, t, s, g float64, opts ...Option) *LatencyAware {
+	return &LatencyAware{
+		questionBank: bank,
+		model:        bkt.NewLatencyBKT(l0, t, s, g, params),
+		picker:       newPicker(bank, opts),
+	}
+}
+
+func (la *LatencyAware) SelectQuestion(ctx SelectionContext) (*SelectionResult, error) {
+	allQuestions, err := la.questionBank.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	unanswered := filterUnanswered(allQuestions, ctx.Answered)
+	if len(unanswered) == 0 {
+		return nil, fmt.Errorf("no unanswered questions left")
+	}
+	question := la.picker.pick(rankByDifficulty(unanswered, la.model.GetCurrentKnowledge()), ctx.Answered)
+
+	return &SelectionResult{Question: question}, nil
+}
+
+// PrepareNextQuestion updates the model from the last answer and its response time
+func (la *LatencyAware) PrepareNextQuestion(ctx SelectionContext) error {
+	if len(ctx.History) == 0 {
+		return nil
+	}
+	last := ctx.History[len(ctx.History)-1]
+	la.model.UpdateTimed(last.Score, last.HintHelp, time.Duration(last.LatencyMS)*time.Millisecond)
+	return nil
+}
+
+func (la *LatencyAware) LearnerModel() *LearnerSnapshot {
+	return &LearnerSnapshot{
+		Model: "bkt_latency",
+		Values: map[string]float64{
+			"knowledge":     la.model.GetCurrentKnowledge(),
+			"guess":         la.model.Guess(),
+			"rapid_guesses": float64(la.model.RapidGuesses()),
+		},
+		Knowledge: la.model.GetCurrentKnowledge(),
+	}
+}
+
+// Metrics include the latency-adjusted knowledge after each answer and the
+// learner's estimated guess rate
+func (la *LatencyAware) Metrics() map[string]interface{} {
+	return map[string]interface{}{
+		"latency_knowledge_history": la.model.GetKnowledgeHistory(),
+		"estimated_guess":           la.model.Guess(),
+		"rapid_guesses":             la.model.RapidGuesses(),
+	}
+}
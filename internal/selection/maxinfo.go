@@ -0,0 +1,143 @@
+package selection
+
+import (
+	"fmt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/irt"
+	"math"
+)
+
+// IRT MAXIMUM-INFORMATION SELECTION (computerized adaptive testing)
+
+// AbilityEstimate is the learner's ability on the IRT theta scale after a given number of answers
+type AbilityEstimate struct {
+	Theta         float64
+	StandardError float64
+}
+
+type MaxInfo struct {
+	questionBank content.QuestionBank
+	picker       *picker
+	estimates    []AbilityEstimate // One entry per estimate, starting with the prior
+}
+
+func NewMaxInfo(bank content.QuestionBank, opts ...Option) *MaxInfo {
+	theta, se := irt.EstimateEAP(nil)
+	return &MaxInfo{
+		questionBank: bank,
+		picker:       newPicker(bank, opts),
+		estimates:    []AbilityEstimate{{Theta: theta, StandardError: se}},
+	}
+}
+
+func (mi *MaxInfo) SelectQuestion(ctx SelectionContext) (*SelectionResult, error) {
+	allQuestions, err := mi.questionBank.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := mi.estimate(ctx.History)
+	if err != nil {
+		return nil, err
+	}
+
+	unanswered := filterUnanswered(allQuestions, ctx.Answered)
+	if len(unanswered) == 0 {
+		return nil, fmt.Errorf("no unanswered questions left")
+	}
+	best := mi.picker.pick(rankByInformation(unanswered, estimate.Theta), ctx.Answered)
+
+	return &SelectionResult{
+		Question: best,
+		SelectionReasoning: fmt.Sprintf("Selected the item with the most information at your estimated ability (θ = %.2f ± %.2f).",
+			estimate.Theta, estimate.StandardError),
+	}, nil
+}
+
+// PrepareNextQuestion re-estimates ability with the newest answer
+func (mi *MaxInfo) PrepareNextQuestion(ctx SelectionContext) error {
+	estimate, err := mi.estimate(ctx.History)
+	if err != nil {
+		return err
+	}
+	mi.estimates = append(mi.estimates, estimate)
+	return nil
+}
+
+func (mi *MaxInfo) Feedback() string {
+	return ""
+}
+
+func (mi *MaxInfo) LearnerModel() *LearnerSnapshot {
+	current := mi.currentEstimate()
+	return &LearnerSnapshot{
+		Model: "irt",
+		Values: map[string]float64{
+			"theta":          current.Theta,
+			"standard_error": current.StandardError,
+		},
+		// Probability of answering an item of average difficulty
+		Knowledge: 1 / (1 + math.Exp(-current.Theta)),
+	}
+}
+
+// Metrics include the ability estimate and its standard error after each answer
+func (mi *MaxInfo) Metrics() map[string]interface{} {
+	current := mi.currentEstimate()
+	abilityHistory := make([]float64, 0, len(mi.estimates))
+	seHistory := make([]float64, 0, len(mi.estimates))
+	for _, e := range mi.estimates {
+		abilityHistory = append(abilityHistory, e.Theta)
+		seHistory = append(seHistory, e.StandardError)
+	}
+	return map[string]interface{}{
+		"ability":            current.Theta,
+		"ability_se":         current.StandardError,
+		"ability_history":    abilityHistory,
+		"ability_se_history": seHistory,
+	}
+}
+
+// currentEstimate returns the most recent ability estimate (the prior before any answers)
+func (mi *MaxInfo) currentEstimate() AbilityEstimate {
+	if len(mi.estimates) == 0 {
+		return AbilityEstimate{Theta: 0, StandardError: 1}
+	}
+	return mi.estimates[len(mi.estimates)-1]
+}
+
+func (mi *MaxInfo) estimate(history []content.AnswerRecord) (AbilityEstimate, error) {
+	responses, err := irtResponses(mi.questionBank, history)
+	if err != nil {
+		return AbilityEstimate{}, err
+	}
+	theta, se := irt.EstimateEAP(responses)
+	return AbilityEstimate{Theta: theta, StandardError: se}, nil
+}
+
+func irtResponses(bank content.QuestionBank, history []content.AnswerRecord) ([]irt.Response, error) {
+	responses := make([]irt.Response, 0, len(history))
+	for _, record := range history {
+		question, err := bank.GetQuestionByID(record.QuestionID)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, irt.Response{
+			Item:    irtItem(question),
+			Score:   record.Score,
+		})
+	}
+	return responses, nil
+}
+
+func irtItem(q *content.Question) irt.Item {
+	p := q.Metadata.IRT
+	return irt.Item{A: p.A, B: p.B, C: p.C}
+}
+
+// rankByInformation orders questions by Fisher information at theta, most informative first
+func rankByInformation(unanswered []content.Question, theta float64) []content.Question {
+	return rankBy(unanswered, func(q *content.Question) float64 {
+		return irt.Information(theta, irtItem(q))
+	})
+}
@@ -0,0 +1,182 @@
+package selection
+
+import (
+	"go-adapt/internal/content"
+	"go-adapt/internal/eventlog"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// EXPOSURE CONTROL AND CONTENT BALANCING
+//
+// Selectors rank the eligible questions best-first and hand the ranking to a
+// picker, which applies whichever constraints were configured with Options.
+// With no options the picker returns the top-ranked question, so the plain
+// selectors stay deterministic.
+
+type Option func(*picker)
+
+// WithRandomesque picks uniformly among the k best-ranked questions instead of always the best,
+// so learners at the same estimated level don't all see the same items.
+func WithRandomesque(k int) Option {
+	return func(p *picker) {
+		p.topK = k
+	}
+}
+
+// WithSympsonHetter administers each selected question only with its exposure control
+// probability (question ID -> K in [0, 1]); rejected questions pass the turn to the next best.
+// Questions without an entry are always administered.
+func WithSympsonHetter(probabilities map[int]float64) Option {
+	return func(p *picker) {
+		p.exposure = probabilities
+	}
+}
+
+// WithContentBalance requires at least minPerTag[tag] questions carrying each tag within a
+// session of sessionLength questions. Selection stays adaptive until the remaining slots are
+// only just enough to meet the outstanding quotas, then only questions covering them are eligible.
+func WithContentBalance(minPerTag map[string]int, sessionLength int) Option {
+	return func(p *picker) {
+		p.minPerTag = minPerTag
+		p.sessionLength = sessionLength
+	}
+}
+
+// ConstraintOptions turns logged constraints back into options; nil gives none
+func ConstraintOptions(c *eventlog.Constraints) []Option {
+	if c == nil {
+		return nil
+	}
+	var opts []Option
+	if c.ExposureTopK > 1 {
+		opts = append(opts, WithRandomesque(c.ExposureTopK))
+	}
+	if len(c.ExposureControl) > 0 {
+		opts = append(opts, WithSympsonHetter(c.ExposureControl))
+	}
+	if len(c.ContentBalance) > 0 {
+		opts = append(opts, WithContentBalance(c.ContentBalance, c.SessionLength))
+	}
+	return opts
+}
+
+// WithRand sets the random source used by randomesque and Sympson-Hetter selection
+func WithRand(rng *rand.Rand) Option {
+	return func(p *picker) {
+		p.rng = rng
+	}
+}
+
+type picker struct {
+	topK          int
+	exposure      map[int]float64
+	minPerTag     map[string]int
+	sessionLength int
+	rng           *rand.Rand
+	questionBank  content.QuestionBank
+}
+
+func newPicker(bank content.QuestionBank, opts []Option) *picker {
+	p := &picker{questionBank: bank}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.rng == nil {
+		p.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return p
+}
+
+// rankBy orders questions best-first by score (higher is better), keeping bank order for ties
+func rankBy(questions []content.Question, score func(q *content.Question) float64) []content.Question {
+	ranked := make([]content.Question, len(questions))
+	copy(ranked, questions)
+	scores := make(map[int]float64, len(ranked))
+	for i := range ranked {
+		scores[ranked[i].ID] = score(&ranked[i])
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].ID] > scores[ranked[j].ID]
+	})
+	return ranked
+}
+
+// pick chooses one question from a best-first ranking
+func (p *picker) pick(ranked []content.Question, answered []int) *content.Question {
+	candidates := p.balance(ranked, answered)
+	candidates = p.controlExposure(candidates)
+
+	choice := 0
+	if p.topK > 1 {
+		choice = p.rng.Intn(min(p.topK, len(candidates)))
+	}
+	return &candidates[choice]
+}
+
+// balance restricts candidates to questions covering an unmet tag quota once the
+// remaining session slots leave no room for anything else
+func (p *picker) balance(ranked []content.Question, answered []int) []content.Question {
+	if len(p.minPerTag) == 0 {
+		return ranked
+	}
+
+	seen := make(map[string]int)
+	for _, id := range answered {
+		question, err := p.questionBank.GetQuestionByID(id)
+		if err != nil {
+			continue
+		}
+		for _, tag := range question.Metadata.Tags {
+			seen[tag]++
+		}
+	}
+
+	outstanding := 0
+	unmet := make(map[string]bool)
+	for tag, quota := range p.minPerTag {
+		if seen[tag] < quota {
+			outstanding += quota - seen[tag]
+			unmet[tag] = true
+		}
+	}
+
+	remaining := p.sessionLength - len(answered)
+	if outstanding == 0 || outstanding < remaining {
+		return ranked
+	}
+
+	var covering []content.Question
+	for _, q := range ranked {
+		for _, tag := range q.Metadata.Tags {
+			if unmet[tag] {
+				covering = append(covering, q)
+				break
+			}
+		}
+	}
+	if len(covering) == 0 {
+		return ranked // Quota can't be met from what's left in the bank
+	}
+	return covering
+}
+
+// controlExposure drops questions that lose their Sympson-Hetter draw, keeping the best if all do
+func (p *picker) controlExposure(ranked []content.Question) []content.Question {
+	if len(p.exposure) == 0 {
+		return ranked
+	}
+
+	var accepted []content.Question
+	for _, q := range ranked {
+		k, ok := p.exposure[q.ID]
+		if !ok || p.rng.Float64() < k {
+			accepted = append(accepted, q)
+		}
+	}
+	if len(accepted) == 0 {
+		return ranked[:1] // Every draw lost: serve the best question rather than none
+	}
+	return accepted
+}
@@ -0,0 +1,102 @@
+package selection
+
+import (
+	"go-adapt/internal/content"
+	"math/rand"
+	"testing"
+)
+
+func rankedQuestions(ids ...int) []content.Question {
+	questions := make([]content.Question, 0, len(ids))
+	for _, id := range ids {
+		questions = append(questions, content.Question{ID: id})
+	}
+	return questions
+}
+
+func TestPickerDefaultsToBest(t *testing.T) {
+	p := newPicker(content.NewStaticBank(), nil)
+	if got := p.pick(rankedQuestions(3, 1, 2), nil); got.ID != 3 {
+		t.Errorf("picked %d, want the best-ranked 3", got.ID)
+	}
+}
+
+func TestRandomesqueStaysInTopK(t *testing.T) {
+	p := newPicker(content.NewStaticBank(), []Option{WithRandomesque(2), WithRand(rand.New(rand.NewSource(1)))})
+	seen := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		seen[p.pick(rankedQuestions(5, 6, 7, 8), nil).ID] = true
+	}
+	if !seen[5] || !seen[6] || len(seen) != 2 {
+		t.Errorf("picked %v, want both and only the top two", seen)
+	}
+}
+
+func TestSympsonHetter(t *testing.T) {
+	tests := []struct {
+		name     string
+		exposure map[int]float64
+		want     int
+	}{
+		{"never administered passes to the next", map[int]float64{5: 0}, 6},
+		{"always administered", map[int]float64{5: 1}, 5},
+		{"unlisted always administered", map[int]float64{9: 0}, 5},
+		{"every draw lost serves the best", map[int]float64{5: 0, 6: 0, 7: 0}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPicker(content.NewStaticBank(), []Option{WithSympsonHetter(tt.exposure), WithRand(rand.New(rand.NewSource(1)))})
+			if got := p.pick(rankedQuestions(5, 6, 7), nil); got.ID != tt.want {
+				t.Errorf("picked %d, want %d", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentBalanceFillsQuotaAtTheEnd(t *testing.T) {
+	bank := content.NewStaticBank()
+	questions, err := bank.GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Rank the hepatology questions last, so only the quota can bring them forward
+	var tagged, others []content.Question
+	for _, q := range questions {
+		if hasTag(&q, "hepatology") {
+			tagged = append(tagged, q)
+		} else {
+			others = append(others, q)
+		}
+	}
+	if len(tagged) == 0 {
+		t.Fatal("no hepatology questions in the bank")
+	}
+	ranked := append(others, tagged...)
+	p := newPicker(bank, []Option{WithContentBalance(map[string]int{"hepatology": 1}, 3)})
+
+	if got := p.pick(ranked, []int{others[0].ID}); hasTag(got, "hepatology") {
+		t.Errorf("quota forced question %d while two slots were left", got.ID)
+	}
+	if got := p.pick(ranked, []int{others[0].ID, others[1].ID}); !hasTag(got, "hepatology") {
+		t.Errorf("last slot served question %d, which doesn't meet the hepatology quota", got.ID)
+	}
+}
+
+func hasTag(q *content.Question, tag string) bool {
+	for _, t := range q.Metadata.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLLMModesAreUnconstrained(t *testing.T) {
+	r := DefaultRegistry()
+	for _, mode := range r.Modes() {
+		want := mode.Name == "llm" || mode.Name == "hybrid"
+		if mode.Unconstrained != want {
+			t.Errorf("mode %s: Unconstrained = %v, want %v", mode.Name, mode.Unconstrained, want)
+		}
+	}
+}
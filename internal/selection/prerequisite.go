@@ -0,0 +1,184 @@
+package selection
+
+import (
+	"fmt"
+	"go-adapt/internal/bkt"
+	"go-adapt/internal/content"
+	"math"
+	"strings"
+)
+
+// MASTERY-GATED SELECTION OVER A SKILL GRAPH
+//
+// Each skill has its own BKT model. A question is only eligible once every skill
+// it exercises is unlocked, i.e. all of that skill's prerequisites have reached
+// the mastery threshold.
+
+const DefaultMasteryThreshold = 0.8
+
+// SkillFrontier is the learner's position in the skill graph
+type SkillFrontier struct {
+	Mastery  map[string]float64
+	Mastered []string
+	Unlocked []string // Unlocked but not yet mastered: what the learner is working on
+	Locked   []string
+}
+
+type Prerequisite struct {
+	noCapabilities
+	questionBank content.QuestionBank
+	graph        *content.SkillGraph
+	threshold    float64
+	skillModels  map[string]*bkt.BKTModel
+	picker       *picker
+}
+
+func NewPrerequisite(bank content.QuestionBank, graph *content.SkillGraph, threshold, l0, t, s, g float64, opts ...Option) *Prerequisite {
+	models := make(map[string]*bkt.BKTModel)
+	for _, skill := range graph.Skills() {
+		models[skill] = bkt.InitializeBKTModel(l0, t, s, g)
+	}
+	return &Prerequisite{
+		questionBank: bank,
+		graph:        graph,
+		threshold:    threshold,
+		skillModels:  models,
+		picker:       newPicker(bank, opts),
+	}
+}
+
+func (p *Prerequisite) SelectQuestion(ctx SelectionContext) (*SelectionResult, error) {
+	allQuestions, err := p.questionBank.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	unanswered := filterUnanswered(allQuestions, ctx.Answered)
+	if len(unanswered) == 0 {
+		return nil, fmt.Errorf("no unanswered questions left")
+	}
+
+	mastered := p.mastered()
+	var eligible []content.Question
+	for _, q := range unanswered {
+		if p.questionUnlocked(&q, mastered) {
+			eligible = append(eligible, q)
+		}
+	}
+	if len(eligible) == 0 {
+		// Everything left is gated; fall back rather than end the session early
+		question := p.picker.pick(rankByDifficulty(unanswered, ctx.PL0), ctx.Answered)
+		return &SelectionResult{
+			Question:           question,
+			SelectionReasoning: "No unlocked questions remain, so this is the closest match to your overall level.",
+		}, nil
+	}
+
+	// Prefer questions on skills still being learned, at the difficulty matching that skill's mastery
+	question := p.picker.pick(rankBy(eligible, func(q *content.Question) float64 {
+		skills := p.graph.SkillsFor(q)
+		if len(skills) == 0 {
+			return -1 - q.Metadata.Difficulty
+		}
+		score := 0.0
+		for _, skill := range skills {
+			pl := p.skillModels[skill].GetCurrentKnowledge()
+			if mastered[skill] {
+				score -= 1
+			}
+			score -= math.Abs(q.Metadata.Difficulty - pl)
+		}
+		return score / float64(len(skills))
+	}), ctx.Answered)
+
+	return &SelectionResult{
+		Question: question,
+		SelectionReasoning: fmt.Sprintf("Practising %s at a difficulty matched to your current mastery.",
+			strings.Join(p.graph.SkillsFor(question), " and ")),
+	}, nil
+}
+
+// PrepareNextQuestion updates the mastery of every skill the last answer exercised
+func (p *Prerequisite) PrepareNextQuestion(ctx SelectionContext) error {
+	if len(ctx.History) == 0 {
+		return nil
+	}
+	last := ctx.History[len(ctx.History)-1]
+
+	question, err := p.questionBank.GetQuestionByID(last.QuestionID)
+	if err != nil {
+		return err
+	}
+	for _, skill := range p.graph.SkillsFor(question) {
+		p.skillModels[skill].UpdateHinted(last.Score, last.HintHelp)
+	}
+	return nil
+}
+
+// Frontier returns per-skill mastery and which skills are mastered, unlocked and locked
+func (p *Prerequisite) Frontier() SkillFrontier {
+	mastered := p.mastered()
+	frontier := SkillFrontier{
+		Mastery:  make(map[string]float64, len(p.skillModels)),
+		Mastered: []string{},
+		Unlocked: []string{},
+		Locked:   []string{},
+	}
+	for _, skill := range p.graph.Skills() {
+		frontier.Mastery[skill] = p.skillModels[skill].GetCurrentKnowledge()
+		switch {
+		case mastered[skill]:
+			frontier.Mastered = append(frontier.Mastered, skill)
+		case p.graph.Unlocked(skill, mastered):
+			frontier.Unlocked = append(frontier.Unlocked, skill)
+		default:
+			frontier.Locked = append(frontier.Locked, skill)
+		}
+	}
+	return frontier
+}
+
+// LearnerModel reports mean mastery across skills as the learner's knowledge
+func (p *Prerequisite) LearnerModel() *LearnerSnapshot {
+	mastery := p.Frontier().Mastery
+	var sum float64
+	for _, skill := range p.graph.Skills() { // In graph order, so the sum is reproducible
+		sum += mastery[skill]
+	}
+	snapshot := &LearnerSnapshot{Model: "skill_bkt", Values: mastery}
+	if len(mastery) > 0 {
+		snapshot.Knowledge = sum / float64(len(mastery))
+	}
+	return snapshot
+}
+
+// Metrics include per-skill mastery and the mastered/unlocked/locked frontier
+func (p *Prerequisite) Metrics() map[string]interface{} {
+	frontier := p.Frontier()
+	return map[string]interface{}{
+		"skill_mastery":     frontier.Mastery,
+		"mastered_skills":   frontier.Mastered,
+		"unlocked_skills":   frontier.Unlocked,
+		"locked_skills":     frontier.Locked,
+		"mastery_threshold": p.threshold,
+	}
+}
+
+func (p *Prerequisite) mastered() map[string]bool {
+	mastered := make(map[string]bool)
+	for skill, model := range p.skillModels {
+		if model.GetCurrentKnowledge() >= p.threshold {
+			mastered[skill] = true
+		}
+	}
+	return mastered
+}
+
+func (p *Prerequisite) questionUnlocked(q *content.Question, mastered map[string]bool) bool {
+	for _, skill := range p.graph.SkillsFor(q) {
+		if !p.graph.Unlocked(skill, mastered) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,336 @@
+package selection
+
+import (
+	"fmt"
+	"go-adapt/internal/bkt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/llm"
+	"go-adapt/internal/review"
+	"math"
+	"sort"
+	"time"
+)
+
+// SELECTOR REGISTRY
+//
+// Session modes map to named selector factories. Adding a strategy means
+// registering a Mode; nothing in session or handler needs to change.
+
+// Deps are the process-wide resources shared by every session
+type Deps struct {
+	QuestionBank content.QuestionBank
+	LLMClient    *llm.LLMClient
+	BanditStore  *BanditStore
+	ReviewStore  review.Store
+	SkillGraph   *content.SkillGraph
+}
+
+// Config is the per-session configuration passed to a factory
+type Config struct {
+	L0, T, S, G float64        // Session BKT parameters
+	Params      map[string]any // Selector-specific parameters, validated against Mode.Params
+	Options     []Option
+}
+
+// Parameter types accepted in Config.Params
+const (
+	ParamFloat  = "float"
+	ParamInt    = "int"
+	ParamString = "string"
+)
+
+// Param describes one selector-specific parameter
+type Param struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Default     any    `json:"default,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+type Factory func(deps Deps, cfg Config) (Selector, error)
+
+type Mode struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Params      []Param `json:"params"`
+	// Unconstrained modes choose questions without the picker, so exposure
+	// control and content balancing options can't be applied to them
+	Unconstrained bool    `json:"unconstrained,omitempty"`
+	Factory       Factory `json:"-"`
+}
+
+type Registry struct {
+	modes map[string]Mode
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		modes: make(map[string]Mode),
+	}
+}
+
+func (r *Registry) Register(mode Mode) error {
+	if _, exists := r.modes[mode.Name]; exists {
+		return fmt.Errorf("mode %q already registered", mode.Name)
+	}
+	r.modes[mode.Name] = mode
+	return nil
+}
+
+func (r *Registry) Mode(name string) (Mode, bool) {
+	mode, ok := r.modes[name]
+	return mode, ok
+}
+
+// Modes returns every registered mode sorted by name
+func (r *Registry) Modes() []Mode {
+	modes := make([]Mode, 0, len(r.modes))
+	for _, mode := range r.modes {
+		if mode.Params == nil {
+			mode.Params = []Param{}
+		}
+		modes = append(modes, mode)
+	}
+	sort.Slice(modes, func(i, j int) bool {
+		return modes[i].Name < modes[j].Name
+	})
+	return modes
+}
+
+// New validates cfg.Params against the mode's parameters, fills in defaults and builds the selector
+func (r *Registry) New(name string, deps Deps, cfg Config) (Selector, error) {
+	mode, ok := r.modes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown mode %q", name)
+	}
+
+	params, err := r.ResolveParams(name, cfg.Params)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Params = params
+	return mode.Factory(deps, cfg)
+}
+
+// ResolveParams validates parameters for a mode and fills in defaults
+func (r *Registry) ResolveParams(name string, given map[string]any) (map[string]any, error) {
+	mode, ok := r.modes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown mode %q", name)
+	}
+	return validateParams(mode, given)
+}
+
+func validateParams(mode Mode, given map[string]any) (map[string]any, error) {
+	declared := make(map[string]Param, len(mode.Params))
+	for _, p := range mode.Params {
+		declared[p.Name] = p
+	}
+
+	params := make(map[string]any, len(mode.Params))
+	for name, value := range given {
+		p, ok := declared[name]
+		if !ok {
+			return nil, fmt.Errorf("mode %q has no parameter %q", mode.Name, name)
+		}
+		v, err := coerce(p, value)
+		if err != nil {
+			return nil, err
+		}
+		if p.Required && v == "" {
+			return nil, fmt.Errorf("mode %q requires parameter %q to be non-empty", mode.Name, p.Name)
+		}
+		params[name] = v
+	}
+	for _, p := range mode.Params {
+		if _, ok := params[p.Name]; ok {
+			continue
+		}
+		if p.Required {
+			return nil, fmt.Errorf("mode %q requires parameter %q", mode.Name, p.Name)
+		}
+		if p.Default != nil {
+			params[p.Name] = p.Default
+		}
+	}
+	return params, nil
+}
+
+// coerce checks a JSON-decoded value against the parameter type
+func coerce(p Param, value any) (any, error) {
+	switch p.Type {
+	case ParamFloat:
+		if f, ok := value.(float64); ok {
+			return f, nil
+		}
+	case ParamInt:
+		if f, ok := value.(float64); ok && f == math.Trunc(f) {
+			return int(f), nil
+		}
+		if i, ok := value.(int); ok {
+			return i, nil
+		}
+	case ParamString:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("parameter %q must be a %s", p.Name, p.Type)
+}
+
+// Float returns a validated float parameter (zero if absent)
+func (c Config) Float(name string) float64 {
+	f, _ := c.Params[name].(float64)
+	return f
+}
+
+// Int returns a validated int parameter (zero if absent)
+func (c Config) Int(name string) int {
+	i, _ := c.Params[name].(int)
+	return i
+}
+
+// String returns a validated string parameter (empty if absent)
+func (c Config) String(name string) string {
+	s, _ := c.Params[name].(string)
+	return s
+}
+
+// DefaultRegistry returns a registry with every built-in selection strategy
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, mode := range builtinModes() {
+		if err := r.Register(mode); err != nil {
+			panic(err) // Built-in names are fixed, so this is a programming error
+		}
+	}
+	return r
+}
+
+func builtinModes() []Mode {
+	modes := []Mode{
+		{
+			Name:        "bkt",
+			Description: "Rule-based: the unanswered question whose difficulty is closest to BKT's P(L)",
+			Factory: func(deps Deps, cfg Config) (Selector, error) {
+				return NewRuleBased(deps.QuestionBank, cfg.Options...), nil
+			},
+		},
+		{
+			Name:          "llm",
+			Description:   "The LLM analyzes the answer history, selects the next question and writes feedback",
+			Unconstrained: true,
+			Params: []Param{
+				{Name: "prompt_version", Type: ParamString, Description: "System prompt template version", Default: llm.DefaultPromptVersion},
+			},
+			Factory: func(deps Deps, cfg Config) (Selector, error) {
+				if deps.LLMClient == nil {
+					return nil, fmt.Errorf("LLM mode not available - API key not configured")
+				}
+				version := cfg.String("prompt_version")
+				if !deps.LLMClient.HasPromptVersion(version) {
+					return nil, fmt.Errorf("unknown prompt version %q", version)
+				}
+				return NewLLMSelector(deps.QuestionBank, deps.LLMClient, version, cfg.Options...), nil
+			},
+		},
+		{
+			Name:          "hybrid",
+			Description:   "IRT proposes the most informative candidates; the LLM chooses among them and justifies",
+			Unconstrained: true,
+			Params: []Param{
+				{Name: "prompt_version", Type: ParamString, Description: "System prompt template version", Default: llm.DefaultPromptVersion},
+				{Name: "candidates", Type: ParamInt, Description: "Number of IRT candidates offered to the LLM", Default: defaultCandidateCount},
+			},
+			Factory: func(deps Deps, cfg Config) (Selector, error) {
+				if deps.LLMClient == nil {
+					return nil, fmt.Errorf("hybrid mode not available - API key not configured")
+				}
+				version := cfg.String("prompt_version")
+				if !deps.LLMClient.HasPromptVersion(version) {
+					return nil, fmt.Errorf("unknown prompt version %q", version)
+				}
+				if cfg.Int("candidates") < 1 {
+					return nil, fmt.Errorf("candidates must be at least 1")
+				}
+				return NewHybrid(deps.QuestionBank, deps.LLMClient, version, cfg.Int("candidates")), nil
+			},
+		},
+		{
+			Name:        "irt",
+			Description: "Computerized adaptive testing: maximum Fisher information at the EAP ability estimate",
+			Factory: func(deps Deps, cfg Config) (Selector, error) {
+				return NewMaxInfo(deps.QuestionBank, cfg.Options...), nil
+			},
+		},
+		{
+			Name:        "review",
+			Description: "Spaced repetition: due reviews first (SM-2 scheduling), then new items",
+			Params: []Param{
+				{Name: "learner_id", Type: ParamString, Description: "Learner whose review schedule to use", Required: true},
+			},
+			Factory: func(deps Deps, cfg Config) (Selector, error) {
+				return NewReview(deps.QuestionBank, deps.ReviewStore, cfg.String("learner_id"), cfg.Options...), nil
+			},
+		},
+		{
+			Name:        "prereq",
+			Description: "Mastery-gated: only questions whose prerequisite skills are mastered",
+			Params: []Param{
+				{Name: "mastery_threshold", Type: ParamFloat, Description: "Per-skill P(L) at which dependent skills unlock", Default: DefaultMasteryThreshold},
+			},
+			Factory: func(deps Deps, cfg Config) (Selector, error) {
+				threshold := cfg.Float("mastery_threshold")
+				if threshold <= 0 || threshold > 1 {
+					return nil, fmt.Errorf("mastery_threshold must be in (0, 1]")
+				}
+				return NewPrerequisite(deps.QuestionBank, deps.SkillGraph, threshold, cfg.L0, cfg.T, cfg.S, cfg.G, cfg.Options...), nil
+			},
+		},
+		{
+			Name:        "bkt-latency",
+			Description: "Rule-based on a BKT estimate that also weighs response times: fluent answers count for more, rapid guesses for less",
+			Params: []Param{
+				{Name: "fast_ms", Type: ParamInt, Description: "Correct answers quicker than this count as fluent", Default: int(bkt.DefaultLatencyParams.Fast.Milliseconds())},
+				{Name: "slow_ms", Type: ParamInt, Description: "Correct answers slower than this count as effortful", Default: int(bkt.DefaultLatencyParams.Slow.Milliseconds())},
+				{Name: "rapid_guess_ms", Type: ParamInt, Description: "Wrong answers quicker than this count as rapid guesses", Default: int(bkt.DefaultLatencyParams.RapidGuess.Milliseconds())},
+			},
+			Factory: func(deps Deps, cfg Config) (Selector, error) {
+				params := bkt.DefaultLatencyParams
+				params.Fast = time.Duration(cfg.Int("fast_ms")) * time.Millisecond
+				params.Slow = time.Duration(cfg.Int("slow_ms")) * time.Millisecond
+				params.RapidGuess = time.Duration(cfg.Int("rapid_guess_ms")) * time.Millisecond
+				if params.Fast < 0 || params.RapidGuess < 0 || params.Slow < params.Fast {
+					return nil, fmt.Errorf("latency thresholds must be non-negative with slow_ms at least fast_ms")
+				}
+				return NewLatencyAware(deps.QuestionBank, params, cfg.L0, cfg.T, cfg.S, cfg.G, cfg.Options...), nil
+			},
+		},
+	}
+
+	for _, strategy := range []BanditStrategy{EpsilonGreedy, UCB1, Thompson} {
+		strategy := strategy
+		mode := Mode{
+			Name:        "bandit-" + string(strategy),
+			Description: fmt.Sprintf("Multi-armed bandit (%s) rewarded by each question's gain in P(L) across all sessions", strategy),
+			Factory: func(deps Deps, cfg Config) (Selector, error) {
+				b := NewBandit(deps.QuestionBank, deps.BanditStore, strategy, cfg.L0, cfg.Options...)
+				if strategy == EpsilonGreedy {
+					b.epsilon = cfg.Float("epsilon")
+					if b.epsilon < 0 || b.epsilon > 1 {
+						return nil, fmt.Errorf("epsilon must be in [0, 1]")
+					}
+				}
+				return b, nil
+			},
+		}
+		if strategy == EpsilonGreedy {
+			mode.Params = []Param{
+				{Name: "epsilon", Type: ParamFloat, Description: "Probability of exploring a random question", Default: defaultEpsilon},
+			}
+		}
+		modes = append(modes, mode)
+	}
+	return modes
+}
@@ -0,0 +1,38 @@
+package selection
+
+import "testing"
+
+func TestResolveParams(t *testing.T) {
+	r := DefaultRegistry()
+	tests := []struct {
+		name    string
+		mode    string
+		given   map[string]any
+		wantErr bool
+		want    map[string]any
+	}{
+		{"defaults filled in", "bandit-egreedy", nil, false, map[string]any{"epsilon": defaultEpsilon}},
+		{"float given", "bandit-egreedy", map[string]any{"epsilon": 0.3}, false, map[string]any{"epsilon": 0.3}},
+		{"int from JSON number", "hybrid", map[string]any{"candidates": float64(3)}, false, map[string]any{"candidates": 3}},
+		{"fractional int", "hybrid", map[string]any{"candidates": 2.5}, true, nil},
+		{"wrong type", "bandit-egreedy", map[string]any{"epsilon": "high"}, true, nil},
+		{"undeclared parameter", "bkt", map[string]any{"epsilon": 0.1}, true, nil},
+		{"unknown mode", "nope", nil, true, nil},
+		{"required given", "review", map[string]any{"learner_id": "ada"}, false, map[string]any{"learner_id": "ada"}},
+		{"required missing", "review", nil, true, nil},
+		{"required empty", "review", map[string]any{"learner_id": ""}, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := r.ResolveParams(tt.mode, tt.given)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			for name, want := range tt.want {
+				if params[name] != want {
+					t.Errorf("%s = %v (%T), want %v (%T)", name, params[name], params[name], want, want)
+				}
+			}
+		})
+	}
+}
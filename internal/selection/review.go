@@ -0,0 +1,101 @@
+package selection
+
+import (
+	"fmt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/review"
+	"time"
+)
+
+// SPACED-REPETITION REVIEW SELECTION
+//
+// Due items come first, most overdue first. Once nothing is due, new items are
+// introduced by difficulty like the rule-based selector, and only after that are
+// items reviewed ahead of schedule, soonest due first.
+
+type Review struct {
+	noCapabilities
+	questionBank content.QuestionBank
+	store        review.Store
+	learnerID    string
+	picker       *picker
+	now          func() time.Time
+}
+
+func NewReview(bank content.QuestionBank, store review.Store, learnerID string, opts ...Option) *Review {
+	return &Review{
+		questionBank: bank,
+		store:        store,
+		learnerID:    learnerID,
+		picker:       newPicker(bank, opts),
+		now:          time.Now,
+	}
+}
+
+func (r *Review) SelectQuestion(ctx SelectionContext) (*SelectionResult, error) {
+	allQuestions, err := r.questionBank.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	cards, err := r.store.Cards(r.learnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	unanswered := filterUnanswered(allQuestions, ctx.Answered)
+	if len(unanswered) == 0 {
+		return nil, fmt.Errorf("no unanswered questions left")
+	}
+
+	now := r.now()
+	var due, fresh, notDue []content.Question
+	for _, q := range unanswered {
+		card, seen := cards[q.ID]
+		switch {
+		case !seen:
+			fresh = append(fresh, q)
+		case card.IsDue(now):
+			due = append(due, q)
+		default:
+			notDue = append(notDue, q)
+		}
+	}
+
+	byDue := func(q *content.Question) float64 {
+		return -float64(cards[q.ID].Due.Unix())
+	}
+
+	var question *content.Question
+	var reasoning string
+	switch {
+	case len(due) > 0:
+		question = r.picker.pick(rankBy(due, byDue), ctx.Answered)
+		reasoning = fmt.Sprintf("Review due since %s.", cards[question.ID].Due.Format("Jan 2"))
+	case len(fresh) > 0:
+		question = r.picker.pick(rankByDifficulty(fresh, ctx.PL0), ctx.Answered)
+		reasoning = "No reviews are due, so this introduces a new item."
+	default:
+		question = r.picker.pick(rankBy(notDue, byDue), ctx.Answered)
+		reasoning = fmt.Sprintf("Early review: this item is next due %s.", cards[question.ID].Due.Format("Jan 2"))
+	}
+
+	return &SelectionResult{
+		Question:           question,
+		SelectionReasoning: reasoning,
+	}, nil
+}
+
+// PrepareNextQuestion reschedules the question that was just answered, graded
+// by its score so partial credit counts for less than full
+func (r *Review) PrepareNextQuestion(ctx SelectionContext) error {
+	if len(ctx.History) == 0 {
+		return nil
+	}
+	last := ctx.History[len(ctx.History)-1]
+	now := r.now()
+
+	_, err := r.store.UpdateCard(r.learnerID, last.QuestionID, func(card review.Card) review.Card {
+		return review.Schedule(card, review.Quality(last.Score), now)
+	})
+	return err
+}
@@ -0,0 +1,58 @@
+package selection
+
+import (
+	"go-adapt/internal/content"
+	"go-adapt/internal/review"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReviewSchedulesByScore(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		score       float64
+		repetitions int // After a first review; 1 if recalled
+		ease        float64
+	}{
+		{1, 1, 2.5},
+		{0.5, 1, 2.36},
+		{0, 0, 1.96},
+	}
+	for _, tt := range tests {
+		store := review.NewMemoryStore()
+		r := NewReview(content.NewStaticBank(), store, "ada")
+		r.now = func() time.Time { return now }
+		history := []content.AnswerRecord{{QuestionID: 1, Score: tt.score, Correct: tt.score == 1}}
+		if err := r.PrepareNextQuestion(SelectionContext{History: history}); err != nil {
+			t.Fatal(err)
+		}
+		cards, _ := store.Cards("ada")
+		if card := cards[1]; card.Repetitions != tt.repetitions || card.Ease < tt.ease-1e-9 || card.Ease > tt.ease+1e-9 {
+			t.Errorf("score %v: repetitions %d, ease %v; want %d, %v", tt.score, card.Repetitions, card.Ease, tt.repetitions, tt.ease)
+		}
+	}
+}
+
+func TestConcurrentReviewSessionsKeepEveryReview(t *testing.T) {
+	store := review.NewMemoryStore()
+	const sessions = 10
+	var wg sync.WaitGroup
+	for range sessions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := NewReview(content.NewStaticBank(), store, "ada")
+			history := []content.AnswerRecord{{QuestionID: 1, Score: 1, Correct: true}}
+			if err := r.PrepareNextQuestion(SelectionContext{History: history}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	cards, _ := store.Cards("ada")
+	if cards[1].Repetitions != sessions {
+		t.Errorf("%d sessions reviewed question 1 but the card records %d repetitions", sessions, cards[1].Repetitions)
+	}
+}
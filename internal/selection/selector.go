@@ -1,6 +1,7 @@
 package selection
 
 import (
+	"fmt"
 	"go-adapt/internal/content"
 	"go-adapt/internal/llm"
 	"math"
@@ -18,23 +19,61 @@ type SelectionResult struct {
 type Selector interface {
 	SelectQuestion(ctx SelectionContext) (*SelectionResult, error)
 	PrepareNextQuestion(ctx SelectionContext) error // Prepare next question (LLM analyzes here)
+
+	// Feedback on the most recent answer, available after PrepareNextQuestion.
+	// Empty means the session falls back to the question's static feedback.
+	Feedback() string
+	// LearnerModel is the selector's own estimate of the learner, nil if it relies on the session's BKT model.
+	// Its Knowledge is what the learner is shown after each answer.
+	LearnerModel() *LearnerSnapshot
+	// Metrics are selector-specific entries merged into the session metrics
+	Metrics() map[string]interface{}
+}
+
+// LearnerSnapshot is a point-in-time view of a selector's learner model
+type LearnerSnapshot struct {
+	Model  string             `json:"model"` // Which model produced the values, e.g. "llm" or "irt"
+	Values map[string]float64 `json:"values"`
+	// Knowledge is the model's overall P(L), reported to the learner after each
+	// answer; 0 reports none
+	Knowledge float64 `json:"knowledge,omitempty"`
 }
 
+// noCapabilities gives selectors without feedback, learner model or metrics
+// of their own the optional half of the Selector interface.
+type noCapabilities struct{}
+
+func (noCapabilities) Feedback() string { return "" }
+
+func (noCapabilities) LearnerModel() *LearnerSnapshot { return nil }
+
+func (noCapabilities) Metrics() map[string]interface{} { return nil }
+
 type SelectionContext struct {
 	PL0 float64
 	Answered []int
 	History  []content.AnswerRecord
+	OnLLMCall func(resp *llm.LLMResponse) // Optional: called after every LLM call the selector makes
+}
+
+func (ctx SelectionContext) reportLLMCall(resp *llm.LLMResponse) {
+	if ctx.OnLLMCall != nil {
+		ctx.OnLLMCall(resp)
+	}
 }
 
 //RULE BASED SELECTION
 
 type RuleBased struct {
+	noCapabilities
 	questionBank content.QuestionBank
+	picker *picker
 }
 
-func NewRuleBased(bank content.QuestionBank) *RuleBased {
+func NewRuleBased(bank content.QuestionBank, opts ...Option) *RuleBased {
     return &RuleBased{
         questionBank: bank,
+        picker: newPicker(bank, opts),
     }
 }
 
@@ -45,7 +84,10 @@ func (rb *RuleBased) SelectQuestion(ctx SelectionContext) (*SelectionResult, err
 	}
 
 	unanswered := filterUnanswered(allQuestions, ctx.Answered)
-	bestQuestion := findClosestDifficulty(unanswered, ctx.PL0)
+	if len(unanswered) == 0 {
+		return nil, fmt.Errorf("no unanswered questions left")
+	}
+	bestQuestion := rb.picker.pick(rankByDifficulty(unanswered, ctx.PL0), ctx.Answered)
 
 	return &SelectionResult{
 		Question: bestQuestion,
@@ -58,19 +100,11 @@ func (rb *RuleBased) PrepareNextQuestion(ctx SelectionContext) error {
 	return nil // Rule-based doesn't need preparation
 }
 
-func findClosestDifficulty(unanswered []content.Question, targetPL float64) *content.Question {
-    // implementation
-	var closestQuestion = unanswered[0]
-	var minDiff = math.Abs(float64(closestQuestion.Metadata.Difficulty) - targetPL)
-
-	for i, u := range unanswered{
-		diff := math.Abs(float64(u.Metadata.Difficulty) - targetPL)
-		if diff < minDiff{
-			minDiff = diff
-			closestQuestion = unanswered[i]
-		}
-	}
-	return &closestQuestion
+// rankByDifficulty orders questions by how close their difficulty is to targetPL
+func rankByDifficulty(unanswered []content.Question, targetPL float64) []content.Question {
+	return rankBy(unanswered, func(q *content.Question) float64 {
+		return -math.Abs(q.Metadata.Difficulty - targetPL)
+	})
 }
 
 // LLM based selector
@@ -78,16 +112,27 @@ func findClosestDifficulty(unanswered []content.Question, targetPL float64) *con
 type LLMSelector struct{
 	questionBank content.QuestionBank
 	llmClient *llm.LLMClient
+	promptVersion string
+	picker *picker // Only used for the first question, which is chosen without the LLM
 	cachedResult *SelectionResult // Cache for next question
+	lastFeedback string // Feedback on the most recent answer
+	lastUserModel *llm.UserModel // Latest LLM user model
 }
 
-func NewLLMSelector(qb content.QuestionBank, client *llm.LLMClient) *LLMSelector{
+func NewLLMSelector(qb content.QuestionBank, client *llm.LLMClient, promptVersion string, opts ...Option) *LLMSelector{
 	return & LLMSelector{
 		questionBank: qb,
 		llmClient: client,
+		promptVersion: promptVersion,
+		picker: newPicker(qb, opts),
 	}
 }
 
+// PromptVersion returns the system prompt version this selector sends to the LLM
+func (ls *LLMSelector) PromptVersion() string {
+	return ls.promptVersion
+}
+
 func (ls *LLMSelector) SelectQuestion(ctx SelectionContext) (*SelectionResult, error){
 	// If we have a cached result, return it
 	if ls.cachedResult != nil {
@@ -105,7 +150,7 @@ func (ls *LLMSelector) SelectQuestion(ctx SelectionContext) (*SelectionResult, e
 
 		// Find question with difficulty closest to 0.1
 		unanswered := filterUnanswered(allQuestions, ctx.Answered)
-		firstQuestion := findClosestDifficulty(unanswered, 0.1)
+		firstQuestion := ls.picker.pick(rankByDifficulty(unanswered, 0.1), ctx.Answered)
 
 		return &SelectionResult{
 			Question:           firstQuestion,
@@ -121,10 +166,11 @@ func (ls *LLMSelector) SelectQuestion(ctx SelectionContext) (*SelectionResult, e
 		return nil, err
 	}
 
-	llmResponse, err := ls.llmClient.SelectNextQuestion(allQuestions, ctx.History)
+	llmResponse, err := ls.llmClient.SelectNextQuestion(ls.promptVersion, allQuestions, ctx.History)
 	if err != nil {
 		return nil, err
 	}
+	ctx.reportLLMCall(llmResponse)
 
 	question, err := ls.questionBank.GetQuestionByID(llmResponse.QuestionID)
 	if err != nil {
@@ -139,15 +185,20 @@ func (ls *LLMSelector) SelectQuestion(ctx SelectionContext) (*SelectionResult, e
 
 // PrepareNextQuestion calls LLM to analyze performance and cache next question
 func (ls *LLMSelector) PrepareNextQuestion(ctx SelectionContext) error {
+	// Nothing from the previous answer may outlive a failed call
+	ls.cachedResult = nil
+	ls.lastFeedback = ""
+
 	allQuestions, err := ls.questionBank.GetAll()
 	if err != nil {
 		return err
 	}
 
-	llmResponse, err := ls.llmClient.SelectNextQuestion(allQuestions, ctx.History)
+	llmResponse, err := ls.llmClient.SelectNextQuestion(ls.promptVersion, allQuestions, ctx.History)
 	if err != nil {
 		return err
 	}
+	ctx.reportLLMCall(llmResponse)
 
 	question, err := ls.questionBank.GetQuestionByID(llmResponse.QuestionID)
 	if err != nil {
@@ -161,13 +212,49 @@ func (ls *LLMSelector) PrepareNextQuestion(ctx SelectionContext) error {
 		SelectionReasoning: llmResponse.SelectionReasoning,
 		UserModel:          llmResponse.UserModel,
 	}
+	ls.lastFeedback = llmResponse.Feedback
+	if llmResponse.UserModel != nil {
+		ls.lastUserModel = llmResponse.UserModel
+	}
 
 	return nil
 }
 
-// GetCachedResult returns the cached result without consuming it
-func (ls *LLMSelector) GetCachedResult() *SelectionResult {
-	return ls.cachedResult
+func (ls *LLMSelector) Feedback() string {
+	return ls.lastFeedback
+}
+
+func (ls *LLMSelector) LearnerModel() *LearnerSnapshot {
+	return userModelSnapshot(ls.lastUserModel)
+}
+
+func (ls *LLMSelector) Metrics() map[string]interface{} {
+	metrics := map[string]interface{}{
+		"prompt_version": ls.promptVersion,
+	}
+	if ls.lastUserModel != nil {
+		metrics["user_model"] = userModelSnapshot(ls.lastUserModel).Values
+	}
+	return metrics
+}
+
+// userModelSnapshot converts the LLM's self-reported user model, empty before
+// the first LLM call. It reports no Knowledge: the LLM's knowledge level is
+// shown in the metrics only.
+func userModelSnapshot(um *llm.UserModel) *LearnerSnapshot {
+	if um == nil {
+		return &LearnerSnapshot{Model: "llm"}
+	}
+	return &LearnerSnapshot{
+		Model: "llm",
+		Values: map[string]float64{
+			"knowledge_level":      um.KnowledgeLevel,
+			"confidence":           um.Confidence,
+			"learning_rate":        um.LearningRate,
+			"pattern_consistency":  um.PatternConsistency,
+			"difficulty_tolerance": um.DifficultyTolerance,
+		},
+	}
 }
 
 // Private helper functions (lowercase)
@@ -0,0 +1,89 @@
+package session
+
+import "go-adapt/internal/content"
+
+// Calibration compares how sure the learner said they were with how they did,
+// over the answers they rated
+type Calibration struct {
+	Rated                 int      `json:"rated"`
+	OverconfidentErrors   int      `json:"overconfident_errors"`   // Wrong answers rated confident
+	UnderconfidentCorrect int      `json:"underconfident_correct"` // Correct answers rated unsure
+	ConfidentAccuracy     *float64 `json:"confident_accuracy"`     // nil until an answer is rated confident
+	UnsureAccuracy        *float64 `json:"unsure_accuracy"`        // nil until an answer is rated unsure
+	BrierScore            *float64 `json:"brier_score"`            // Mean squared gap between rating and score; 0 is perfectly calibrated
+}
+
+// Misconception is a wrong answer the learner was confident in
+type Misconception struct {
+	QuestionID int      `json:"question_id"`
+	UserAnswer string   `json:"user_answer"`
+	Confidence int      `json:"confidence"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+func calibrate(history []content.AnswerRecord) Calibration {
+	var cal Calibration
+	var confident, confidentCorrect, unsure, unsureCorrect int
+	squaredError := 0.0
+	for _, record := range history {
+		if record.Confidence == 0 {
+			continue
+		}
+		cal.Rated++
+		gap := content.ConfidenceProbability(record.Confidence) - record.Score
+		squaredError += gap * gap
+
+		if record.Confidence >= content.ConfidentFrom {
+			confident++
+			if record.Correct {
+				confidentCorrect++
+			}
+		} else {
+			unsure++
+			if record.Correct {
+				unsureCorrect++
+			}
+		}
+		if record.LikelyMisconception() {
+			cal.OverconfidentErrors++
+		}
+		if record.Underconfident() {
+			cal.UnderconfidentCorrect++
+		}
+	}
+	cal.ConfidentAccuracy = ratio(confidentCorrect, confident)
+	cal.UnsureAccuracy = ratio(unsureCorrect, unsure)
+	if cal.Rated > 0 {
+		brier := squaredError / float64(cal.Rated)
+		cal.BrierScore = &brier
+	}
+	return cal
+}
+
+func ratio(n, d int) *float64 {
+	if d == 0 {
+		return nil
+	}
+	r := float64(n) / float64(d)
+	return &r
+}
+
+// likelyMisconceptions lists the confident errors in history, oldest first
+func (sm *SessionManager) likelyMisconceptions() []Misconception {
+	misconceptions := []Misconception{}
+	for _, record := range sm.answerHistory {
+		if !record.LikelyMisconception() {
+			continue
+		}
+		m := Misconception{
+			QuestionID: record.QuestionID,
+			UserAnswer: record.UserAnswer,
+			Confidence: record.Confidence,
+		}
+		if question, err := sm.questionBank.GetQuestionByID(record.QuestionID); err == nil {
+			m.Tags = question.Metadata.Tags
+		}
+		misconceptions = append(misconceptions, m)
+	}
+	return misconceptions
+}
@@ -1,10 +1,15 @@
 package session
 
 import (
+	"errors"
 	"go-adapt/internal/bkt"
 	"go-adapt/internal/content"
+	"go-adapt/internal/eventlog"
 	"go-adapt/internal/llm"
 	"go-adapt/internal/selection"
+	"log"
+	"strings"
+	"time"
 )
 
 /*Purpose: Coordinates BKT model and question selector for a learning session
@@ -28,8 +33,8 @@ import (
     - Call selector.SelectQuestion()
     - Retrieve full question from bank by ID
     - Return question
-  - SubmitAnswer(questionID int, correct bool) float64
-    - Update BKT model (UpdateCorrect or UpdateIncorrect)
+  - SubmitAnswer(questionID int, userAnswer string, grade content.Grade, meta AnswerMeta) *SubmitAnswerResult
+    - Update BKT model from the grade's score (UpdateGraded)
     - Add questionID to answeredIDs
     - Add to answerHistory
     - Return new currentKnowledge
@@ -45,28 +50,43 @@ type SessionManager struct{
 	answeredIDs []int
 	answerHistory []content.AnswerRecord
 	mode string
-	lastUserModel *llm.UserModel // Latest LLM user model (nil for BKT mode)
+
+	// Event logging, off until EnableEventLog is called
+	sessionID string
+	events eventlog.Logger
+	servedAt time.Time // When the current question was served, for answer latency
+	now func() time.Time // The clock; replay substitutes logged event times
+	timings []ResponseTiming
+
+	optionSeed *int64 // Shuffles served options when set, see ShuffleOptions
+
+	// Hints revealed for the question being answered, see UseHint
+	currentID int
+	hintsUsed int
+	hintTiers int
 }
 
+var (
+	// ErrNotCurrentQuestion means a hint was asked for a question other than the one being answered
+	ErrNotCurrentQuestion = errors.New("question is not the one being answered")
+	// ErrNoMoreHints means every hint for the question has been shown
+	ErrNoMoreHints = errors.New("no more hints for this question")
+)
+
 type QuestionResult struct {
 	Question           *content.Question
 	Feedback           string
 	SelectionReasoning string
 }
 
-func NewSessionManager(questionBank content.QuestionBank, mode string, llmClient *llm.LLMClient, l0, t, s, g float64) *SessionManager{
-	var selector selection.Selector
-	if mode == "llm" {
-		selector = selection.NewLLMSelector(questionBank, llmClient)
-	} else {
-		selector = selection.NewRuleBased(questionBank)
-	}
-
+// NewSessionManager runs a session with a selector built for mode (see selection.Registry)
+func NewSessionManager(questionBank content.QuestionBank, mode string, selector selection.Selector, l0, t, s, g float64) *SessionManager{
 	return &SessionManager{
 		bktModel: bkt.InitializeBKTModel(l0,t,s,g),
 		questionBank: questionBank,
 		selector: selector,
 		mode: mode,
+		now: time.Now,
 	}
 }
 
@@ -76,76 +96,280 @@ func (sm *SessionManager) GetNextQuestion() (*QuestionResult, error){
 		Answered: sm.answeredIDs,
 		History: sm.answerHistory,
 	}
-	result, err := sm.selector.SelectQuestion(ctx)
+	result, err := sm.selector.SelectQuestion(sm.withEvents(ctx))
 	if err != nil {
 		return nil, err
 	}
+
+	question := *result.Question
+	if sm.optionSeed != nil {
+		question = question.WithShuffledOptions(*sm.optionSeed)
+	}
+	question.Hints = nil // Revealed one at a time, see UseHint
+
+	sm.setCurrent(question.ID)
+
+	sm.servedAt = sm.now()
+	sm.logEvent(eventlog.Event{
+		Type:       eventlog.QuestionServed,
+		QuestionID: question.ID,
+		Reasoning:  result.SelectionReasoning,
+		Options:    question.Options,
+	})
+
 	return &QuestionResult{
-		Question:           result.Question,
+		Question:           &question,
 		Feedback:           result.Feedback,
 		SelectionReasoning: result.SelectionReasoning,
 	}, nil
 }
 
+// AnswerMeta is what the client reports about an answer besides the answer itself
+type AnswerMeta struct {
+	ClientLatency time.Duration // Measured by the client, excluding network time; 0 if not measured
+	Confidence    int           // The learner's rating, see content.MaxConfidence; 0 if not rated
+}
+
+// ResponseTiming is when a question was served and answered
+type ResponseTiming struct {
+	QuestionID      int       `json:"question_id"`
+	ServedAt        time.Time `json:"served_at"`
+	SubmittedAt     time.Time `json:"submitted_at"`
+	ServerLatencyMS int64     `json:"server_latency_ms"`
+	ClientLatencyMS int64     `json:"client_latency_ms,omitempty"`
+	LatencyMS       int64     `json:"latency_ms"` // The one the models use, see answerLatency
+}
+
+// answerLatency prefers the client's measurement, which leaves out network
+// time, unless it is longer than the server saw and so can't be right
+func answerLatency(server, client time.Duration) time.Duration {
+	if client > 0 && (server <= 0 || client <= server) {
+		return client
+	}
+	return server
+}
+
 type SubmitAnswerResult struct {
-	CurrentKnowledge float64
-	Feedback         string
+	CurrentKnowledge    float64
+	Feedback            string
+	LikelyMisconception bool // Wrong but rated confident, see content.AnswerRecord.LikelyMisconception
 }
 
-func (sm *SessionManager) SubmitAnswer(questionID int, correct bool) *SubmitAnswerResult {
-	// Always update BKT for tracking (used for comparison in LLM mode)
-	if !correct {
-		sm.bktModel.UpdateIncorrect()
-	} else {
-		sm.bktModel.UpdateCorrect()
+// misconceptionNote leads the feedback on a confident wrong answer
+const misconceptionNote = "You were confident in this answer, so it may be a misconception worth revisiting rather than a slip."
+
+// SubmitAnswer records a graded answer. Knowledge is updated from the grade's
+// score, so partial credit counts as partial evidence of mastery, and hints
+// used on the question weaken the evidence of a correct answer. Latency is
+// measured from when the question was served. A wrong answer rated confident
+// is flagged as a likely misconception.
+func (sm *SessionManager) SubmitAnswer(questionID int, userAnswer string, grade content.Grade, meta AnswerMeta) *SubmitAnswerResult {
+	submittedAt := sm.now()
+	var serverLatency time.Duration
+	if !sm.servedAt.IsZero() && questionID == sm.currentID {
+		serverLatency = submittedAt.Sub(sm.servedAt)
 	}
+	latency := answerLatency(serverLatency, meta.ClientLatency)
+	sm.timings = append(sm.timings, ResponseTiming{
+		QuestionID:      questionID,
+		ServedAt:        sm.servedAt,
+		SubmittedAt:     submittedAt,
+		ServerLatencyMS: serverLatency.Milliseconds(),
+		ClientLatencyMS: meta.ClientLatency.Milliseconds(),
+		LatencyMS:       latency.Milliseconds(),
+	})
 
-	sm.answeredIDs = append(sm.answeredIDs, questionID)
-	sm.answerHistory = append(sm.answerHistory, content.AnswerRecord{
+	hintsUsed, hintHelp := 0, 0.0
+	if questionID == sm.currentID {
+		hintsUsed, hintHelp = sm.hintsUsed, content.HintHelp(sm.hintsUsed, sm.hintTiers)
+	}
+	sm.currentID, sm.hintsUsed, sm.hintTiers = 0, 0, 0
+
+	sm.logEvent(eventlog.Event{
+		Type:       eventlog.AnswerSubmitted,
 		QuestionID: questionID,
-		Correct:    correct,
+		UserAnswer: userAnswer,
+		Correct:    grade.Correct,
+		Score:      grade.Score,
+		MatchType:  string(grade.Match),
+		LatencyMS:  serverLatency.Milliseconds(),
+		ClientLatencyMS: meta.ClientLatency.Milliseconds(),
+		Confidence: meta.Confidence,
+		HintsUsed:  hintsUsed,
+		HintHelp:   hintHelp,
 	})
 
-	// Prepare next question (LLM analyzes performance here)
+	// Always update BKT for tracking (used for comparison in LLM mode)
+	sm.bktModel.UpdateHinted(grade.Score, hintHelp)
+
+	sm.answeredIDs = append(sm.answeredIDs, questionID)
+	record := content.AnswerRecord{
+		QuestionID: questionID,
+		Correct:    grade.Correct,
+		Score:      grade.Score,
+		UserAnswer: userAnswer,
+		HintsUsed:  hintsUsed,
+		HintHelp:   hintHelp,
+		LatencyMS:  latency.Milliseconds(),
+		Confidence: meta.Confidence,
+	}
+	sm.answerHistory = append(sm.answerHistory, record)
+
 	ctx := selection.SelectionContext{
 		PL0:      sm.bktModel.GetCurrentKnowledge(),
 		Answered: sm.answeredIDs,
 		History:  sm.answerHistory,
 	}
 
-	feedback := ""
-	// Get feedback based on mode
-	if sm.mode == "llm" {
-		// LLM mode: get personalized feedback from LLM
-		sm.selector.PrepareNextQuestion(ctx)
-		// Peek at cached result to get feedback and user model without consuming it
-		if llmSelector, ok := sm.selector.(*selection.LLMSelector); ok {
-			if llmSelector.GetCachedResult() != nil {
-				cached := llmSelector.GetCachedResult()
-				feedback = cached.Feedback
-				sm.lastUserModel = cached.UserModel // Store latest user model
-			}
-		}
-	} else {
-		// BKT mode: use static feedback from question
-		sm.selector.PrepareNextQuestion(ctx)
+	// Prepare next question; selectors that analyze performance (e.g. the LLM) do it here.
+	// A failure isn't fatal: feedback falls back to static and selection to the selector's fallback.
+	if err := sm.selector.PrepareNextQuestion(sm.withEvents(ctx)); err != nil {
+		log.Printf("session %s: preparing the next question failed: %v", sm.sessionID, err)
+	}
+
+	knowledgeEvent := eventlog.Event{
+		Type:       eventlog.KnowledgeUpdated,
+		QuestionID: questionID,
+		Knowledge:  sm.bktModel.GetCurrentKnowledge(),
+	}
+	if snapshot := sm.selector.LearnerModel(); snapshot != nil {
+		knowledgeEvent.LearnerModel = snapshot.Values
+	}
+	sm.logEvent(knowledgeEvent)
+
+	// Rubric feedback is about this exact answer, so it comes first; then the
+	// selector's own feedback, then static feedback for the chosen option
+	feedback := grade.Feedback
+	if feedback == "" {
+		feedback = sm.selector.Feedback()
+	}
+	if feedback == "" {
 		question, err := sm.questionBank.GetQuestionByID(questionID)
 		if err == nil {
-			feedback = question.Feedback
+			feedback = content.ResolveFeedback(question, userAnswer)
 		}
 	}
+	if record.LikelyMisconception() {
+		feedback = strings.TrimSpace(misconceptionNote + " " + feedback)
+	}
 
-	knowledge := 0.0
-	if sm.mode == "bkt" {
-		knowledge = sm.bktModel.GetCurrentKnowledge()
+	// The selector decides what knowledge to report; without a learner model of
+	// its own, that is the session's BKT estimate
+	knowledge := sm.bktModel.GetCurrentKnowledge()
+	if snapshot := sm.selector.LearnerModel(); snapshot != nil {
+		knowledge = snapshot.Knowledge
 	}
 
 	return &SubmitAnswerResult{
-		CurrentKnowledge: knowledge,
-		Feedback:         feedback,
+		CurrentKnowledge:    knowledge,
+		Feedback:            feedback,
+		LikelyMisconception: record.LikelyMisconception(),
 	}
 }
 
+// setCurrent marks the question being answered. Serving the same question
+// again (e.g. on reload) keeps the hints already shown.
+func (sm *SessionManager) setCurrent(questionID int) {
+	if questionID != sm.currentID {
+		sm.currentID, sm.hintsUsed, sm.hintTiers = questionID, 0, 0
+	}
+}
+
+// UseHint reveals the next hint for the question being answered, which has
+// tiers hints, and returns the 0-based tier to show
+func (sm *SessionManager) UseHint(questionID, tiers int) (int, error) {
+	if questionID == 0 || questionID != sm.currentID {
+		return 0, ErrNotCurrentQuestion
+	}
+	if sm.hintsUsed >= tiers {
+		return 0, ErrNoMoreHints
+	}
+	sm.hintTiers = tiers
+	sm.hintsUsed++
+	sm.logEvent(eventlog.Event{
+		Type:       eventlog.HintRequested,
+		QuestionID: questionID,
+		HintsUsed:  sm.hintsUsed,
+		HintTiers:  tiers,
+	})
+	return sm.hintsUsed - 1, nil
+}
+
+// ShuffleOptions serves every question's options in an order fixed by seed
+// and the question, instead of as authored. Call it before EnableEventLog so
+// the seed is logged with the session.
+func (sm *SessionManager) ShuffleOptions(seed int64) {
+	sm.optionSeed = &seed
+}
+
+// SetClock replaces the clock used to time answers, for sessions that don't
+// run in real time such as simulations
+func (sm *SessionManager) SetClock(now func() time.Time) {
+	sm.now = now
+}
+
+// EnableEventLog records this session's events from now on, starting with session_started.
+// Replayed sessions never call it, so replaying doesn't log again.
+func (sm *SessionManager) EnableEventLog(sessionID, learnerID string, params map[string]any, constraints *eventlog.Constraints, events eventlog.Logger) {
+	sm.sessionID = sessionID
+	sm.events = events
+
+	l0, t, s, g := sm.bktModel.GetParameters()
+	sm.logEvent(eventlog.Event{
+		Type:        eventlog.SessionStarted,
+		LearnerID:   learnerID,
+		Mode:        sm.mode,
+		Params:      params,
+		BKT:         &eventlog.BKTParams{L0: l0, T: t, S: s, G: g},
+		OptionSeed:  sm.optionSeed,
+		Constraints: constraints,
+	})
+}
+
+// Complete records that the session has ended
+func (sm *SessionManager) Complete() {
+	sm.logEvent(eventlog.Event{
+		Type:          eventlog.SessionCompleted,
+		AnsweredCount: len(sm.answeredIDs),
+	})
+}
+
+func (sm *SessionManager) logEvent(event eventlog.Event) {
+	if sm.events == nil {
+		return
+	}
+	event.SessionID = sm.sessionID
+	if event.Time.IsZero() {
+		event.Time = sm.now() // The session's clock, so replay sees the latencies the session did
+	}
+	if err := sm.events.Log(event); err != nil {
+		log.Printf("session %s: failed to log %s event: %v", sm.sessionID, event.Type, err)
+	}
+}
+
+// withEvents lets selectors report their LLM calls to the event log
+func (sm *SessionManager) withEvents(ctx selection.SelectionContext) selection.SelectionContext {
+	if sm.events == nil {
+		return ctx
+	}
+	ctx.OnLLMCall = func(resp *llm.LLMResponse) {
+		event := eventlog.Event{
+			Type:          eventlog.LLMCall,
+			QuestionID:    resp.QuestionID,
+			PromptVersion: resp.PromptVersion,
+		}
+		if resp.Usage != nil {
+			event.InputTokens = resp.Usage.InputTokens
+			event.OutputTokens = resp.Usage.OutputTokens
+			event.CacheReadInputTokens = resp.Usage.CacheReadInputTokens
+			event.CacheCreationInputTokens = resp.Usage.CacheCreationInputTokens
+			event.LatencyMS = resp.Usage.Latency.Milliseconds()
+		}
+		sm.logEvent(event)
+	}
+	return ctx
+}
+
 func (sm *SessionManager) GetAnsweredCount() int{
 	return len(sm.answeredIDs)
 }
@@ -173,41 +397,53 @@ func (sm *SessionManager) GetMetrics() map[string]interface{} {
 	metrics["difficulty_history"] = difficultyHistory
 	metrics["mode"] = sm.mode
 
-	if sm.mode == "bkt" {
-		// BKT-specific metrics
-		l0, t, s, g := sm.bktModel.GetParameters()
-
-		metrics["knowledge_history"] = sm.bktModel.GetKnowledgeHistory()
-		metrics["answer_history"] = sm.bktModel.GetAnswerHistory()
-		metrics["current_knowledge"] = sm.bktModel.GetCurrentKnowledge()
-		metrics["parameters"] = map[string]float64{
-			"l0": l0,
-			"t":  t,
-			"s":  s,
-			"g":  g,
-		}
-	} else if sm.mode == "llm" {
-		// LLM-specific metrics
-		if sm.lastUserModel != nil {
-			metrics["user_model"] = map[string]float64{
-				"knowledge_level":      sm.lastUserModel.KnowledgeLevel,
-				"confidence":           sm.lastUserModel.Confidence,
-				"learning_rate":        sm.lastUserModel.LearningRate,
-				"pattern_consistency":  sm.lastUserModel.PatternConsistency,
-				"difficulty_tolerance": sm.lastUserModel.DifficultyTolerance,
-			}
+	// BKT runs in every mode, as the primary model or for comparison
+	l0, t, s, g := sm.bktModel.GetParameters()
+	metrics["knowledge_history"] = sm.bktModel.GetKnowledgeHistory()
+	metrics["answer_history"] = sm.bktModel.GetAnswerHistory()
+	metrics["score_history"] = sm.bktModel.GetScoreHistory()
+
+	// Hinted correct answers raise knowledge less than unaided ones
+	hintsHistory := make([]int, 0, len(sm.answerHistory))
+	hintedAnswers := 0
+	for _, record := range sm.answerHistory {
+		hintsHistory = append(hintsHistory, record.HintsUsed)
+		if record.HintsUsed > 0 {
+			hintedAnswers++
 		}
+	}
+	metrics["hints_history"] = hintsHistory
+	metrics["hinted_answers"] = hintedAnswers
 
-		// Include BKT's current knowledge for comparison
-		metrics["current_knowledge"] = sm.bktModel.GetCurrentKnowledge()
+	// Confident errors suggest misconceptions rather than gaps
+	confidenceHistory := make([]int, 0, len(sm.answerHistory))
+	for _, record := range sm.answerHistory {
+		confidenceHistory = append(confidenceHistory, record.Confidence)
+	}
+	metrics["confidence_history"] = confidenceHistory
+	metrics["calibration"] = calibrate(sm.answerHistory)
+	metrics["likely_misconceptions"] = sm.likelyMisconceptions()
 
-		// Also include answer history for LLM mode
-		answerHistory := make([]bool, len(sm.answerHistory))
-		for i, record := range sm.answerHistory {
-			answerHistory[i] = record.Correct
-		}
-		metrics["answer_history"] = answerHistory
+	latencyHistory := make([]int64, 0, len(sm.timings))
+	for _, timing := range sm.timings {
+		latencyHistory = append(latencyHistory, timing.LatencyMS)
+	}
+	metrics["latency_history"] = latencyHistory
+	metrics["response_times"] = sm.timings
+	metrics["current_knowledge"] = sm.bktModel.GetCurrentKnowledge()
+	metrics["parameters"] = map[string]float64{
+		"l0": l0,
+		"t":  t,
+		"s":  s,
+		"g":  g,
+	}
+
+	if snapshot := sm.selector.LearnerModel(); snapshot != nil {
+		metrics["learner_model"] = snapshot
+	}
+	for k, v := range sm.selector.Metrics() {
+		metrics[k] = v
 	}
 
 	return metrics
-}
\ No newline at end of file
+}
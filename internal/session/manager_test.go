@@ -0,0 +1,131 @@
+package session
+
+import (
+	"go-adapt/internal/content"
+	"go-adapt/internal/llm"
+	"go-adapt/internal/llm/llmtest"
+	"go-adapt/internal/selection"
+	"testing"
+)
+
+const llmReply = `<feedback>Feedback on the first answer.</feedback>
+<next_question_id>2</next_question_id>
+<selection_reasoning>Next in difficulty.</selection_reasoning>`
+
+// multipleChoiceBank is the static bank without the questions that need typed answers or a rubric grader
+type multipleChoiceBank struct {
+	*content.StaticBank
+}
+
+func (b multipleChoiceBank) GetAll() ([]content.Question, error) {
+	all, err := b.StaticBank.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var questions []content.Question
+	for _, q := range all {
+		if q.QuestionType() == content.MultipleChoice {
+			questions = append(questions, q)
+		}
+	}
+	return questions, nil
+}
+
+func TestFailedLLMCallFallsBackToStaticFeedback(t *testing.T) {
+	prompts, err := llm.LoadDefaultPrompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bank := multipleChoiceBank{content.NewStaticBank()}
+	for _, tc := range []struct {
+		name  string
+		build func(client *llm.LLMClient) selection.Selector
+	}{
+		{"llm", func(client *llm.LLMClient) selection.Selector {
+			return selection.NewLLMSelector(bank, client, llm.DefaultPromptVersion)
+		}},
+		{"hybrid", func(client *llm.LLMClient) selection.Selector {
+			return selection.NewHybrid(bank, client, llm.DefaultPromptVersion, 3)
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := llmtest.NewProvider(llmtest.Text(llmReply), llmtest.Error(500, "overloaded"))
+			sm := NewSessionManager(bank, tc.name, tc.build(provider.Client(prompts)), 0.02, 0.1, 0.05, 0.2)
+
+			answer := func() *SubmitAnswerResult {
+				next, err := sm.GetNextQuestion()
+				if err != nil {
+					t.Fatal(err)
+				}
+				q, _ := bank.GetQuestionByID(next.Question.ID)
+				wrong := q.Options[0]
+				if wrong == q.Answer {
+					wrong = q.Options[1]
+				}
+				grade, err := q.Grade([]string{wrong})
+				if err != nil {
+					t.Fatal(err)
+				}
+				result := sm.SubmitAnswer(q.ID, wrong, grade, AnswerMeta{})
+				if result.CurrentKnowledge != 0 {
+					t.Errorf("%s mode reported knowledge %v, want none", tc.name, result.CurrentKnowledge)
+				}
+				if want := content.ResolveFeedback(q, wrong); result.Feedback != "Feedback on the first answer." && result.Feedback != want {
+					t.Errorf("feedback %q is neither the LLM's nor the static %q", result.Feedback, want)
+				}
+				return result
+			}
+
+			if first := answer(); first.Feedback != "Feedback on the first answer." {
+				t.Fatalf("first answer: feedback %q, want the LLM's", first.Feedback)
+			}
+			if second := answer(); second.Feedback == "Feedback on the first answer." {
+				t.Error("second answer got the first answer's feedback after the LLM call failed")
+			}
+			if provider.Calls() != 2 {
+				t.Errorf("LLM called %d times, want 2", provider.Calls())
+			}
+		})
+	}
+}
+
+func TestSubmitAnswerReportsTheSelectorsKnowledge(t *testing.T) {
+	for _, tc := range []struct {
+		mode  string
+		model string // Learner model reported, "" for the session's BKT
+	}{
+		{"bkt", ""},
+		{"bandit-ucb", ""},
+		{"irt", "irt"},
+		{"prereq", "skill_bkt"},
+		{"bkt-latency", "bkt_latency"},
+	} {
+		t.Run(tc.mode, func(t *testing.T) {
+			deps := replayDeps(t)
+			selector, err := selection.DefaultRegistry().New(tc.mode, deps, selection.Config{L0: 0.02, T: 0.1, S: 0.05, G: 0.2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sm := NewSessionManager(deps.QuestionBank, tc.mode, selector, 0.02, 0.1, 0.05, 0.2)
+			next, err := sm.GetNextQuestion()
+			if err != nil {
+				t.Fatal(err)
+			}
+			q := next.Question
+			result := sm.SubmitAnswer(q.ID, q.Answer, content.Grade{Correct: true, Score: 1}, AnswerMeta{})
+
+			want := sm.GetCurrentKnowledge()
+			if snapshot := selector.LearnerModel(); snapshot != nil {
+				if snapshot.Model != tc.model {
+					t.Fatalf("learner model %q, want %q", snapshot.Model, tc.model)
+				}
+				want = snapshot.Knowledge
+			} else if tc.model != "" {
+				t.Fatalf("no learner model, want %q", tc.model)
+			}
+			if result.CurrentKnowledge <= 0 || result.CurrentKnowledge >= 1 || result.CurrentKnowledge != want {
+				t.Errorf("reported knowledge %v, want %v", result.CurrentKnowledge, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package session
+
+import (
+	"fmt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/eventlog"
+	"go-adapt/internal/grading"
+	"go-adapt/internal/review"
+	"go-adapt/internal/selection"
+	"time"
+)
+
+// SelectorBuilder rebuilds the selector a session was started with
+type SelectorBuilder func(mode string, params map[string]any, opts []selection.Option, l0, t, s, g float64) (selection.Selector, error)
+
+// RegistryBuilder builds replayed selectors from registry. Every selector gets
+// an in-memory copy of deps.BanditStore (or an empty store when it is nil),
+// so bandit replays rank questions by what has been learned, and an empty
+// review store. Replaying never changes the statistics and schedules live
+// sessions share. LLM-backed modes still call deps.LLMClient.
+func RegistryBuilder(registry *selection.Registry, deps selection.Deps) SelectorBuilder {
+	learned := deps.BanditStore
+	return func(mode string, params map[string]any, opts []selection.Option, l0, t, s, g float64) (selection.Selector, error) {
+		deps := deps
+		if learned != nil {
+			deps.BanditStore = learned.Copy()
+		} else {
+			deps.BanditStore = selection.NewBanditStore()
+		}
+		deps.ReviewStore = review.NewMemoryStore()
+		return registry.New(mode, deps, selection.Config{L0: l0, T: t, S: s, G: g, Params: params, Options: opts})
+	}
+}
+
+// Replay reconstructs a SessionManager from one session's events by starting
+// it with the logged mode, parameters and constraints and resubmitting every
+// logged answer. BKT state, answered questions, hints used, response times,
+// confidence ratings and history are restored exactly, using the logged event
+// times as the clock. Selectors are fed the same answers again: LLM-backed
+// selectors will call the LLM again, and selectors with stores write to them,
+// so build selectors with RegistryBuilder or without shared stores.
+func Replay(events []eventlog.Event, questionBank content.QuestionBank, build SelectorBuilder) (*SessionManager, error) {
+	var sm *SessionManager
+	for _, e := range events {
+		switch e.Type {
+		case eventlog.SessionStarted:
+			if sm != nil {
+				return nil, fmt.Errorf("session %s started twice", e.SessionID)
+			}
+			if e.BKT == nil {
+				return nil, fmt.Errorf("session %s: session_started has no BKT parameters", e.SessionID)
+			}
+			selector, err := build(e.Mode, e.Params, selection.ConstraintOptions(e.Constraints), e.BKT.L0, e.BKT.T, e.BKT.S, e.BKT.G)
+			if err != nil {
+				return nil, fmt.Errorf("session %s: %w", e.SessionID, err)
+			}
+			sm = NewSessionManager(questionBank, e.Mode, selector, e.BKT.L0, e.BKT.T, e.BKT.S, e.BKT.G)
+			if e.OptionSeed != nil {
+				sm.ShuffleOptions(*e.OptionSeed)
+			}
+
+		case eventlog.QuestionServed, eventlog.HintRequested:
+			if sm == nil {
+				return nil, fmt.Errorf("session %s: %s before session_started", e.SessionID, e.Type)
+			}
+			sm.setCurrent(e.QuestionID)
+			if e.Type == eventlog.QuestionServed {
+				sm.servedAt = e.Time
+			}
+			if e.Type == eventlog.HintRequested {
+				if _, err := sm.UseHint(e.QuestionID, e.HintTiers); err != nil {
+					return nil, fmt.Errorf("session %s: %w", e.SessionID, err)
+				}
+			}
+
+		case eventlog.AnswerSubmitted:
+			if sm == nil {
+				return nil, fmt.Errorf("session %s: answer before session_started", e.SessionID)
+			}
+			grade := content.Grade{Correct: e.Correct, Score: e.Score, Match: grading.MatchType(e.MatchType)}
+			if e.Correct && e.Score == 0 {
+				grade.Score = 1 // Logged before partial credit, or omitted as implied
+			}
+			submittedAt := e.Time
+			sm.now = func() time.Time { return submittedAt }
+			sm.SubmitAnswer(e.QuestionID, e.UserAnswer, grade, AnswerMeta{
+				ClientLatency: time.Duration(e.ClientLatencyMS) * time.Millisecond,
+				Confidence:    e.Confidence,
+			})
+		}
+	}
+	if sm == nil {
+		return nil, fmt.Errorf("no session_started event")
+	}
+	return sm, nil
+}
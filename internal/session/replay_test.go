@@ -0,0 +1,174 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-adapt/internal/content"
+	"go-adapt/internal/eventlog"
+	"go-adapt/internal/review"
+	"go-adapt/internal/selection"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// jsonlLog keeps events as the JSON Lines writer would write them
+type jsonlLog struct {
+	buf bytes.Buffer
+}
+
+func (l *jsonlLog) Log(event eventlog.Event) error {
+	return json.NewEncoder(&l.buf).Encode(event)
+}
+
+func (l *jsonlLog) events(t *testing.T) []eventlog.Event {
+	t.Helper()
+	events, err := eventlog.ReadAll(bytes.NewReader(l.buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func replayDeps(t *testing.T) selection.Deps {
+	t.Helper()
+	graph, err := content.NewMedicalTerminologySkillGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return selection.Deps{
+		QuestionBank: multipleChoiceBank{content.NewStaticBank()},
+		BanditStore:  selection.NewBanditStore(),
+		ReviewStore:  review.NewMemoryStore(),
+		SkillGraph:   graph,
+	}
+}
+
+// runLoggedSession answers six questions on a simulated clock, with hints,
+// confidence ratings and client latencies, and returns the session and its events
+func runLoggedSession(t *testing.T, deps selection.Deps, mode string, params map[string]any, constraints *eventlog.Constraints) (*SessionManager, []eventlog.Event) {
+	t.Helper()
+	registry := selection.DefaultRegistry()
+	l0, tr, s, g := 0.02, 0.1, 0.05, 0.2
+	selector, err := registry.New(mode, deps, selection.Config{L0: l0, T: tr, S: s, G: g, Params: params, Options: selection.ConstraintOptions(constraints)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := registry.ResolveParams(mode, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sm := NewSessionManager(deps.QuestionBank, mode, selector, l0, tr, s, g)
+	clock := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	sm.SetClock(func() time.Time { return clock })
+	sm.ShuffleOptions(7)
+	logged := &jsonlLog{}
+	sm.EnableEventLog("s1", "learner-1", resolved, constraints, logged)
+
+	for i := 0; i < 6; i++ {
+		next, err := sm.GetNextQuestion()
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := deps.QuestionBank.GetQuestionByID(next.Question.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 {
+			if _, err := sm.UseHint(q.ID, 3); err != nil {
+				t.Fatal(err)
+			}
+		}
+		answer := q.Answer
+		if i%3 == 2 {
+			answer = q.Options[0]
+			if answer == q.Answer {
+				answer = q.Options[1]
+			}
+		}
+		grade, err := q.Grade([]string{answer})
+		if err != nil {
+			t.Fatal(err)
+		}
+		clock = clock.Add(time.Duration(3+i) * time.Second)
+		sm.SubmitAnswer(q.ID, answer, grade, AnswerMeta{ClientLatency: time.Duration(2+i) * time.Second, Confidence: 1 + i%4})
+		clock = clock.Add(time.Second)
+	}
+	sm.Complete()
+	return sm, logged.events(t)
+}
+
+func metricsJSON(t *testing.T, sm *SessionManager) string {
+	t.Helper()
+	data, err := json.Marshal(sm.GetMetrics())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestReplayRestoresSession(t *testing.T) {
+	constraints := &eventlog.Constraints{
+		ExposureTopK:   2,
+		ContentBalance: map[string]int{"hepatology": 1},
+		SessionLength:  6,
+	}
+	for _, mode := range []string{"bkt", "irt", "prereq", "bkt-latency", "bandit-ucb"} {
+		t.Run(mode, func(t *testing.T) {
+			deps := replayDeps(t)
+			live, events := runLoggedSession(t, deps, mode, nil, constraints)
+
+			started := events[0]
+			if started.Type != eventlog.SessionStarted || !reflect.DeepEqual(started.Constraints, constraints) {
+				t.Fatalf("session_started logged constraints %+v, want %+v", started.Constraints, constraints)
+			}
+
+			replayed, err := Replay(events, deps.QuestionBank, RegistryBuilder(selection.DefaultRegistry(), deps))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := metricsJSON(t, replayed), metricsJSON(t, live); got != want {
+				t.Errorf("replayed metrics differ\n got: %s\nwant: %s", got, want)
+			}
+			if !reflect.DeepEqual(replayed.answerHistory, live.answerHistory) {
+				t.Errorf("replayed history %+v, want %+v", replayed.answerHistory, live.answerHistory)
+			}
+		})
+	}
+}
+
+func TestReplayLeavesSharedStoresAlone(t *testing.T) {
+	for _, tc := range []struct {
+		mode   string
+		params map[string]any
+	}{
+		{"bandit-ucb", nil},
+		{"review", map[string]any{"learner_id": "learner-1"}},
+	} {
+		t.Run(tc.mode, func(t *testing.T) {
+			deps := replayDeps(t)
+			_, events := runLoggedSession(t, deps, tc.mode, tc.params, nil)
+			arms, pulls := deps.BanditStore.Snapshot()
+			cards, err := deps.ReviewStore.Cards("learner-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := Replay(events, deps.QuestionBank, RegistryBuilder(selection.DefaultRegistry(), deps)); err != nil {
+				t.Fatal(err)
+			}
+			afterArms, afterPulls := deps.BanditStore.Snapshot()
+			if afterPulls != pulls || !reflect.DeepEqual(afterArms, arms) {
+				t.Errorf("replay changed the bandit store: %d pulls, then %d", pulls, afterPulls)
+			}
+			afterCards, err := deps.ReviewStore.Cards("learner-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(afterCards, cards) {
+				t.Errorf("replay changed the review schedule: %v, then %v", cards, afterCards)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package simulation
+
+import (
+	"go-adapt/internal/content"
+	"math"
+	"math/rand"
+)
+
+// Learner is a synthetic student with a known latent state. Answer draws a
+// response to a question and then applies any learning from the attempt.
+type Learner interface {
+	Answer(q *content.Question) bool
+	// Mastery is the ground-truth probability the learner has mastered the material, in [0, 1]
+	Mastery() float64
+}
+
+// wholeBank is the single skill of a curriculum without a skill graph
+const wholeBank = "all"
+
+// Curriculum is what synthetic learners learn: the skills, which of them each
+// question exercises, and which must be known before another can be learned.
+type Curriculum struct {
+	graph *content.SkillGraph // nil treats the whole bank as one skill
+}
+
+func NewCurriculum(graph *content.SkillGraph) Curriculum {
+	return Curriculum{graph: graph}
+}
+
+func (c Curriculum) Skills() []string {
+	if c.graph == nil {
+		return []string{wholeBank}
+	}
+	return c.graph.Skills()
+}
+
+// SkillsOf returns the skills a question exercises. A question the skill graph
+// doesn't cover exercises every skill.
+func (c Curriculum) SkillsOf(q *content.Question) []string {
+	if c.graph == nil {
+		return []string{wholeBank}
+	}
+	if skills := c.graph.SkillsFor(q); len(skills) > 0 {
+		return skills
+	}
+	return c.graph.Skills()
+}
+
+// ready reports whether every prerequisite of skill passes mastered
+func (c Curriculum) ready(skill string, mastered func(skill string) bool) bool {
+	if c.graph == nil {
+		return true
+	}
+	for _, prereq := range c.graph.Prerequisites(skill) {
+		if !mastered(prereq) {
+			return false
+		}
+	}
+	return true
+}
+
+// itemFit is how much of the learning rate an attempt delivers: all of it when
+// the learner had an even chance of knowing the answer, less for items far too
+// easy or too hard. known is that chance.
+func itemFit(known float64) float64 {
+	return 4 * known * (1 - known)
+}
+
+// difficultyFit is the chance, for itemFit, that a learner at level (the
+// fraction of the curriculum known) knows an item of the given difficulty, both in [0, 1]
+func difficultyFit(level, difficulty float64) float64 {
+	return 1 / (1 + math.Exp(-4*(level-difficulty)))
+}
+
+// BKTLearner follows the BKT generative model, one hidden known/unknown state
+// per skill. A question is answered correctly with probability 1-S when every
+// skill it exercises is known and G when not. After each attempt, each unknown
+// skill of the question whose prerequisites are known flips to known with
+// probability T scaled by how well the question's difficulty suits the learner.
+type BKTLearner struct {
+	Known      map[string]bool
+	T          float64
+	S          float64
+	G          float64
+	curriculum Curriculum
+	rng        *rand.Rand
+}
+
+// NewBKTLearner knows each skill with probability l0 if it knows the skill's prerequisites
+func NewBKTLearner(rng *rand.Rand, curriculum Curriculum, l0, t, s, g float64) *BKTLearner {
+	known := make(map[string]bool)
+	isKnown := func(skill string) bool { return known[skill] }
+	for _, skill := range curriculum.Skills() { // Prerequisites first
+		known[skill] = curriculum.ready(skill, isKnown) && rng.Float64() < l0
+	}
+	return &BKTLearner{
+		Known:      known,
+		T:          t,
+		S:          s,
+		G:          g,
+		curriculum: curriculum,
+		rng:        rng,
+	}
+}
+
+func (l *BKTLearner) Answer(q *content.Question) bool {
+	skills := l.curriculum.SkillsOf(q)
+	p := 1 - l.S
+	for _, skill := range skills {
+		if !l.Known[skill] {
+			p = l.G
+			break
+		}
+	}
+	correct := l.rng.Float64() < p
+
+	learn := l.T * itemFit(difficultyFit(l.Mastery(), q.Metadata.Difficulty))
+	isKnown := func(skill string) bool { return l.Known[skill] }
+	for _, skill := range skills {
+		if !l.Known[skill] && l.curriculum.ready(skill, isKnown) && l.rng.Float64() < learn {
+			l.Known[skill] = true
+		}
+	}
+	return correct
+}
+
+// Mastery is the fraction of skills known
+func (l *BKTLearner) Mastery() float64 {
+	known := 0
+	for _, k := range l.Known {
+		if k {
+			known++
+		}
+	}
+	return float64(known) / float64(len(l.Known))
+}
+
+// IRTLearner answers by the 3PL model using the question's IRT parameters,
+// with an ability per skill; a question draws on the mean ability over the
+// skills it exercises. Each attempt raises those skills' abilities by up to
+// LearningRate, the full rate at a 50% chance of knowing the answer and less
+// for items that are far too easy or too hard.
+type IRTLearner struct {
+	Theta        map[string]float64
+	LearningRate float64
+	curriculum   Curriculum
+	rng          *rand.Rand
+}
+
+// NewIRTLearner starts every skill's ability near theta
+func NewIRTLearner(rng *rand.Rand, curriculum Curriculum, theta, learningRate float64) *IRTLearner {
+	abilities := make(map[string]float64)
+	for _, skill := range curriculum.Skills() {
+		abilities[skill] = theta + skillSpread*rng.NormFloat64()
+	}
+	return &IRTLearner{
+		Theta:        abilities,
+		LearningRate: learningRate,
+		curriculum:   curriculum,
+		rng:          rng,
+	}
+}
+
+// skillSpread is the standard deviation of a learner's skill abilities around their overall ability
+const skillSpread = 0.5
+
+func (l *IRTLearner) Answer(q *content.Question) bool {
+	skills := l.curriculum.SkillsOf(q)
+	var theta float64
+	for _, skill := range skills {
+		theta += l.Theta[skill]
+	}
+	theta /= float64(len(skills))
+
+	item := q.Metadata.IRT
+	known := 1 / (1 + math.Exp(-item.A*(theta-item.B)))
+	p := item.C + (1-item.C)*known
+	correct := l.rng.Float64() < p
+	for _, skill := range skills {
+		l.Theta[skill] += l.LearningRate * itemFit(known)
+	}
+	return correct
+}
+
+// Mastery is the probability of knowing (not guessing) an item of average
+// difficulty, averaged over skills
+func (l *IRTLearner) Mastery() float64 {
+	skills := l.curriculum.Skills()
+	var sum float64
+	for _, skill := range skills {
+		sum += 1 / (1 + math.Exp(-l.Theta[skill]))
+	}
+	return sum / float64(len(skills))
+}
@@ -0,0 +1,280 @@
+package simulation
+
+import (
+	"fmt"
+	"go-adapt/internal/content"
+	"go-adapt/internal/grading"
+	"go-adapt/internal/selection"
+	"go-adapt/internal/session"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Generative models synthetic learners can be drawn from
+const (
+	ModelBKT = "bkt"
+	ModelIRT = "irt"
+)
+
+// DefaultMasteryThreshold is the ground-truth mastery that counts as mastered
+// for each learner model. A BKT learner's mastery is the fraction of skills
+// it knows, so 0.95 means every skill. An IRT learner's is a probability that
+// only nears 1 at abilities far above the bank's items, so it uses a lower bar.
+var DefaultMasteryThreshold = map[string]float64{
+	ModelBKT: 0.95,
+	ModelIRT: 0.8,
+}
+
+// simulatedLatency is how long every synthetic answer takes: between the fast
+// and slow thresholds, so latency-aware models treat it as uninformative
+const simulatedLatency = 10 * time.Second
+
+// Config describes a batch of simulated sessions for one selection mode
+type Config struct {
+	Mode             string
+	Params           map[string]any // Mode parameters, as in StartSessionRequest.Params
+	LearnerModel     string         // ModelBKT or ModelIRT
+	Runs             int
+	Questions        int     // Answers per session
+	MasteryThreshold float64 // Ground-truth mastery that counts as mastered; 0 uses DefaultMasteryThreshold
+	Seed             int64
+	Workers          int
+
+	// Estimator parameters: the session's BKT model
+	L0, T, S, G float64
+}
+
+// RunResult is the outcome of one simulated session
+type RunResult struct {
+	InitialMastery    float64
+	FinalMastery      float64
+	QuestionsToMaster int    // -1 if ground-truth mastery was never reached, 0 if the learner started there
+	Estimator         string // Whose estimate the errors measure, see Estimate
+	FinalError        float64
+	SquaredErrors     []float64 // (estimate - true mastery)^2 after each answer
+}
+
+// Report aggregates RunResults for one mode
+type Report struct {
+	Mode                   string
+	Estimator              string // Whose estimate MeanAbsFinalError and RMSE measure
+	Runs                   int
+	Failed                 int
+	MeanLearningGain       float64
+	AlreadyMastered        int     // Runs whose learner started at mastery, left out of the mastery figures
+	MasteryRate            float64 // Fraction of the other runs reaching mastery
+	MeanQuestionsToMastery float64 // Over runs that reached mastery
+	MeanAbsFinalError      float64
+	RMSE                   float64 // Over every answer of every run
+	FirstError             error   // Why the first failed run failed, nil if none did
+}
+
+// Run simulates cfg.Runs sessions in parallel. Each run gets its own learner
+// and selector seeded from cfg.Seed and the run index, so results are
+// reproducible for a given seed (shared state such as the bandit store aside).
+func Run(registry *selection.Registry, deps selection.Deps, cfg Config) (*Report, error) {
+	if _, ok := registry.Mode(cfg.Mode); !ok {
+		return nil, fmt.Errorf("unknown mode %q", cfg.Mode)
+	}
+	params, err := registry.ResolveParams(cfg.Mode, cfg.Params)
+	if err != nil {
+		return nil, err
+	}
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	if cfg.MasteryThreshold == 0 {
+		threshold, ok := DefaultMasteryThreshold[cfg.LearnerModel]
+		if !ok {
+			threshold = DefaultMasteryThreshold[ModelBKT]
+		}
+		cfg.MasteryThreshold = threshold
+	}
+
+	results := make([]*RunResult, cfg.Runs)
+	errs := make([]error, cfg.Runs)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = runOne(registry, deps, cfg, params, i)
+			}
+		}()
+	}
+	for i := 0; i < cfg.Runs; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return summarize(cfg.Mode, results, errs), nil
+}
+
+func runOne(registry *selection.Registry, deps selection.Deps, cfg Config, params map[string]any, run int) (*RunResult, error) {
+	rng := rand.New(rand.NewSource(cfg.Seed + int64(run)))
+
+	// review mode keys schedules by learner, so give every synthetic learner its own
+	runParams := make(map[string]any, len(params))
+	for k, v := range params {
+		runParams[k] = v
+	}
+	if _, ok := runParams["learner_id"]; ok {
+		runParams["learner_id"] = fmt.Sprintf("sim-%d", run)
+	}
+
+	selector, err := registry.New(cfg.Mode, deps, selection.Config{
+		L0:      cfg.L0,
+		T:       cfg.T,
+		S:       cfg.S,
+		G:       cfg.G,
+		Params:  runParams,
+		Options: []selection.Option{selection.WithRand(rand.New(rand.NewSource(rng.Int63())))},
+	})
+	if err != nil {
+		return nil, err
+	}
+	manager := session.NewSessionManager(deps.QuestionBank, cfg.Mode, selector, cfg.L0, cfg.T, cfg.S, cfg.G)
+
+	learner := newLearner(rng, NewCurriculum(deps.SkillGraph), cfg)
+	result := &RunResult{
+		InitialMastery:    learner.Mastery(),
+		QuestionsToMaster: -1,
+	}
+	if result.InitialMastery >= cfg.MasteryThreshold {
+		result.QuestionsToMaster = 0
+	}
+
+	// Synthetic learners run on a simulated clock so latency-aware models see
+	// plausible, reproducible response times rather than microseconds
+	clock := time.Unix(0, 0)
+	manager.SetClock(func() time.Time { return clock })
+
+	for i := 0; i < cfg.Questions; i++ {
+		next, err := manager.GetNextQuestion()
+		if err != nil {
+			return nil, err
+		}
+		grade := content.Grade{Match: grading.NoMatch}
+		answer := "" // Synthetic learners don't choose a specific distractor
+		if learner.Answer(next.Question) {
+			grade = content.Grade{Correct: true, Score: 1, Match: grading.Exact}
+			answer = next.Question.Answer
+		}
+		clock = clock.Add(simulatedLatency)
+		manager.SubmitAnswer(next.Question.ID, answer, grade, session.AnswerMeta{})
+		clock = clock.Add(time.Second) // Time to read feedback before the next question
+
+		estimator, estimate := Estimate(selector.LearnerModel(), manager.GetCurrentKnowledge())
+		result.Estimator = estimator
+		diff := estimate - learner.Mastery()
+		result.SquaredErrors = append(result.SquaredErrors, diff*diff)
+		if result.QuestionsToMaster < 0 && learner.Mastery() >= cfg.MasteryThreshold {
+			result.QuestionsToMaster = i + 1
+		}
+	}
+
+	result.FinalMastery = learner.Mastery()
+	_, estimate := Estimate(selector.LearnerModel(), manager.GetCurrentKnowledge())
+	result.FinalError = math.Abs(estimate - learner.Mastery())
+	return result, nil
+}
+
+// Estimate puts a selector's own learner model on the scale of
+// Learner.Mastery and names the model it came from. Selectors without a model
+// of their own, or with one that has no mastery scale, are scored on the
+// session's BKT estimate, knowledge.
+func Estimate(snapshot *selection.LearnerSnapshot, knowledge float64) (string, float64) {
+	if snapshot == nil {
+		return "bkt", knowledge
+	}
+	switch snapshot.Model {
+	case "irt":
+		// Probability of knowing an item of average difficulty, as IRTLearner.Mastery
+		return snapshot.Model, 1 / (1 + math.Exp(-snapshot.Values["theta"]))
+	case "llm":
+		return snapshot.Model, snapshot.Values["knowledge_level"]
+	case "bkt_latency":
+		return snapshot.Model, snapshot.Values["knowledge"]
+	case "skill_bkt":
+		if len(snapshot.Values) == 0 {
+			break
+		}
+		var sum float64
+		for _, mastery := range snapshot.Values {
+			sum += mastery
+		}
+		return snapshot.Model, sum / float64(len(snapshot.Values))
+	}
+	return "bkt", knowledge
+}
+
+// newLearner draws a learner from the configured generative model. Latent
+// parameters vary between learners around the estimator's own parameters.
+func newLearner(rng *rand.Rand, curriculum Curriculum, cfg Config) Learner {
+	if cfg.LearnerModel == ModelIRT {
+		theta := rng.NormFloat64()
+		learningRate := math.Max(0, 0.3+0.1*rng.NormFloat64())
+		return NewIRTLearner(rng, curriculum, theta, learningRate)
+	}
+
+	jitter := func(p float64) float64 {
+		return math.Min(0.99, math.Max(0.01, p*(0.5+rng.Float64())))
+	}
+	// Prior knowledge varies far more between learners than the estimator's prior suggests
+	return NewBKTLearner(rng, curriculum, rng.Float64(), jitter(cfg.T), jitter(cfg.S), jitter(cfg.G))
+}
+
+func summarize(mode string, results []*RunResult, errs []error) *Report {
+	report := &Report{Mode: mode}
+
+	var gain, finalErr, sqErr float64
+	var answers, started, mastered, toMastery int
+	for i, r := range results {
+		if errs[i] != nil {
+			report.Failed++
+			if report.FirstError == nil {
+				report.FirstError = errs[i]
+			}
+			continue
+		}
+		report.Runs++
+		report.Estimator = r.Estimator
+		gain += r.FinalMastery - r.InitialMastery
+		finalErr += r.FinalError
+		for _, se := range r.SquaredErrors {
+			sqErr += se
+			answers++
+		}
+		switch {
+		case r.QuestionsToMaster == 0:
+			report.AlreadyMastered++
+			continue
+		case r.QuestionsToMaster > 0:
+			mastered++
+			toMastery += r.QuestionsToMaster
+		}
+		started++
+	}
+
+	if report.Runs > 0 {
+		n := float64(report.Runs)
+		report.MeanLearningGain = gain / n
+		report.MeanAbsFinalError = finalErr / n
+	}
+	if started > 0 {
+		report.MasteryRate = float64(mastered) / float64(started)
+	}
+	if mastered > 0 {
+		report.MeanQuestionsToMastery = float64(toMastery) / float64(mastered)
+	}
+	if answers > 0 {
+		report.RMSE = math.Sqrt(sqErr / float64(answers))
+	}
+	return report
+}
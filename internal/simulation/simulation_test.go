@@ -0,0 +1,157 @@
+package simulation
+
+import (
+	"go-adapt/internal/content"
+	"go-adapt/internal/review"
+	"go-adapt/internal/selection"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func testDeps(t *testing.T) selection.Deps {
+	t.Helper()
+	graph, err := content.NewMedicalTerminologySkillGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return selection.Deps{
+		QuestionBank: content.NewStaticBank(),
+		BanditStore:  selection.NewBanditStore(),
+		ReviewStore:  review.NewMemoryStore(),
+		SkillGraph:   graph,
+	}
+}
+
+func testConfig(mode, learners string) Config {
+	return Config{
+		Mode:         mode,
+		LearnerModel: learners,
+		Runs:         200,
+		Questions:    10,
+		Seed:         1,
+		Workers:      4,
+		L0:           0.02,
+		T:            0.1,
+		S:            0.05,
+		G:            0.2,
+	}
+}
+
+func TestSelectorsGiveDifferentResults(t *testing.T) {
+	for _, learners := range []string{ModelBKT, ModelIRT} {
+		t.Run(learners, func(t *testing.T) {
+			bkt, err := Run(selection.DefaultRegistry(), testDeps(t), testConfig("bkt", learners))
+			if err != nil {
+				t.Fatal(err)
+			}
+			irt, err := Run(selection.DefaultRegistry(), testDeps(t), testConfig("irt", learners))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bkt.Failed > 0 || irt.Failed > 0 {
+				t.Fatalf("runs failed: %v, %v", bkt.FirstError, irt.FirstError)
+			}
+			if bkt.MeanLearningGain == irt.MeanLearningGain {
+				t.Errorf("bkt and irt selection gave the same learning gain, %v", bkt.MeanLearningGain)
+			}
+			if bkt.Estimator != "bkt" || irt.Estimator != "irt" {
+				t.Errorf("estimators %q and %q, want each selector's own", bkt.Estimator, irt.Estimator)
+			}
+			if bkt.RMSE == irt.RMSE {
+				t.Errorf("bkt and irt estimates had the same error, %v", bkt.RMSE)
+			}
+		})
+	}
+}
+
+func TestRunIsReproducible(t *testing.T) {
+	first, err := Run(selection.DefaultRegistry(), testDeps(t), testConfig("prereq", ModelBKT))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := Run(selection.DefaultRegistry(), testDeps(t), testConfig("prereq", ModelBKT))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *first != *again {
+		t.Errorf("same seed gave %+v, then %+v", first, again)
+	}
+}
+
+func TestIRTLearnersReachMastery(t *testing.T) {
+	report, err := Run(selection.DefaultRegistry(), testDeps(t), testConfig("irt", ModelIRT))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.MasteryRate == 0 {
+		t.Errorf("no IRT learner reached mastery at the default threshold %v", DefaultMasteryThreshold[ModelIRT])
+	}
+	if report.AlreadyMastered == report.Runs {
+		t.Error("every learner started at mastery")
+	}
+}
+
+func TestBKTLearnerNeedsPrerequisites(t *testing.T) {
+	deps := testDeps(t)
+	curriculum := NewCurriculum(deps.SkillGraph)
+	// Q4 exercises term construction, which needs roots and suffixes
+	q, err := deps.QuestionBank.GetQuestionByID(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	learner := NewBKTLearner(rand.New(rand.NewSource(1)), curriculum, 0, 1, 0, 0)
+	for i := 0; i < 20; i++ {
+		learner.Answer(q)
+	}
+	if learner.Known["term construction"] {
+		t.Error("learned term construction without its prerequisites")
+	}
+
+	learner.Known["roots"], learner.Known["suffixes"] = true, true
+	for i := 0; i < 20 && !learner.Known["term construction"]; i++ {
+		learner.Answer(q)
+	}
+	if !learner.Known["term construction"] {
+		t.Error("never learned term construction once its prerequisites were known")
+	}
+	if learner.Known["prefixes"] {
+		t.Error("learned a skill the question doesn't exercise")
+	}
+}
+
+func TestIRTLearnerLearnsMostFromMatchedItems(t *testing.T) {
+	curriculum := NewCurriculum(nil)
+	gain := func(b float64) float64 {
+		learner := NewIRTLearner(rand.New(rand.NewSource(1)), curriculum, 0, 0.3)
+		before := learner.Theta[wholeBank]
+		learner.Answer(&content.Question{Metadata: content.QuestionMetadata{IRT: content.IRTParams{A: 1, B: b}}})
+		return learner.Theta[wholeBank] - before
+	}
+	matched := gain(NewIRTLearner(rand.New(rand.NewSource(1)), curriculum, 0, 0.3).Theta[wholeBank])
+	if easy, hard := gain(-4), gain(4); matched <= easy || matched <= hard {
+		t.Errorf("gain %v from a matched item, %v from an easy one, %v from a hard one", matched, easy, hard)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		snapshot *selection.LearnerSnapshot
+		model    string
+		want     float64
+	}{
+		{nil, "bkt", 0.3},
+		{&selection.LearnerSnapshot{Model: "irt", Values: map[string]float64{"theta": 0}}, "irt", 0.5},
+		{&selection.LearnerSnapshot{Model: "llm", Values: map[string]float64{"knowledge_level": 0.7}}, "llm", 0.7},
+		{&selection.LearnerSnapshot{Model: "bkt_latency", Values: map[string]float64{"knowledge": 0.6}}, "bkt_latency", 0.6},
+		{&selection.LearnerSnapshot{Model: "skill_bkt", Values: map[string]float64{"roots": 0.2, "suffixes": 0.6}}, "skill_bkt", 0.4},
+		{&selection.LearnerSnapshot{Model: "skill_bkt", Values: map[string]float64{}}, "bkt", 0.3},
+	}
+	for _, tt := range tests {
+		model, got := Estimate(tt.snapshot, 0.3)
+		if model != tt.model || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Estimate(%+v) = %s %v, want %s %v", tt.snapshot, model, got, tt.model, tt.want)
+		}
+	}
+}